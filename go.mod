@@ -13,6 +13,7 @@ require (
 	github.com/pkg/errors v0.9.0
 	github.com/stretchr/testify v1.4.0
 	golang.org/x/mod v0.2.0 // indirect
+	gopkg.in/yaml.v2 v2.2.7
 )
 
 //replace github.com/KscSDK/ksc-sdk-go => ../../KscSDK/ksc-sdk-go
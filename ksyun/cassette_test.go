@@ -0,0 +1,529 @@
+package ksyun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// cassetteRecordEnv records the api calls of acceptance tests to cassettes when it is "1", or records them
+	// against the fake api when it is "fake", otherwise the tests which have a cassette replay it without calling the real api
+	cassetteRecordEnv  = "KSYUN_RECORD"
+	cassetteRecordFake = "fake"
+	cassetteDir        = "testdata/cassettes"
+	cassetteRedacted   = "REDACTED"
+)
+
+// cassetteSensitiveKeys is the parameter and response field names which are scrubbed from the cassettes,
+// a field is sensitive when its lower case name contains any of them
+var cassetteSensitiveKeys = []string{
+	"password",
+	"secret",
+	"privatekey",
+	"securitytoken",
+	"sessiontoken",
+	"accesskeyid",
+}
+
+// testAccCassettes is the recorder of testAccProvider, the acceptance tests with cassette run one by one
+// by resource.Test because they share the provider
+var testAccCassettes = &cassetteRecorder{}
+
+type cassetteRecorder struct {
+	mu      sync.Mutex
+	current *cassette
+}
+
+type cassette struct {
+	Interactions []*cassetteInteraction `yaml:"interactions"`
+
+	path      string
+	recording bool
+	// endpoint is the fake api which the cassette is recorded against instead of the real api
+	endpoint string
+	mu       sync.Mutex
+	used     []bool
+	// unmatched is the requests which have no unused interaction in the cassette when replaying
+	unmatched []cassetteRequest
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `yaml:"request"`
+	Response cassetteResponse `yaml:"response"`
+}
+
+type cassetteRequest struct {
+	Service string `yaml:"service"`
+	Action  string `yaml:"action"`
+	Method  string `yaml:"method"`
+	// Params is the url encoded query and form parameters, sorted by key
+	Params string `yaml:"params,omitempty"`
+	Body   string `yaml:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status      int    `yaml:"status"`
+	ContentType string `yaml:"content_type,omitempty"`
+	Body        string `yaml:"body"`
+}
+
+// start attaches the cassette of test to the recorder until the returned func is called, the test defers it:
+//
+//	defer testAccCassettes.start(t)()
+//
+// The test without cassette calls the real api, it is skipped when there is no credentials.
+func (r *cassetteRecorder) start(t *testing.T) func() {
+	path := filepath.Join(cassetteDir, strings.Replace(t.Name(), "/", "_", -1)+".yaml")
+	switch os.Getenv(cassetteRecordEnv) {
+	case "1":
+		return r.use(t, &cassette{path: path, recording: true})
+	case cassetteRecordFake:
+		api := newFakeKsyunApi(t)
+		stop := r.use(t, &cassette{path: path, recording: true, endpoint: api.server.URL})
+		return func() {
+			stop()
+			api.server.Close()
+		}
+	}
+	c, err := loadCassette(path)
+	if os.IsNotExist(err) {
+		if os.Getenv("KSYUN_ACCESS_KEY") == "" {
+			t.Skipf("no cassette %s, set KSYUN_ACCESS_KEY and KSYUN_SECRET_KEY to run it with the real api", path)
+		}
+		return func() {}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.use(t, c)
+}
+
+// use attaches c to the recorder, the returned func detaches it and saves the recorded cassette when test passed
+func (r *cassetteRecorder) use(t *testing.T, c *cassette) func() {
+	r.mu.Lock()
+	r.current = c
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		r.current = nil
+		r.mu.Unlock()
+		for _, req := range c.unmatchedRequests() {
+			t.Errorf("cassette %s has no unused interaction of %s %s with %s", c.path, req.Service, req.Action, req.Params)
+		}
+		if c.recording && !t.Failed() {
+			if err := c.save(); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+}
+
+// offline returns true when the requests of test are replayed from a cassette or recorded against the fake api,
+// so no credentials are needed
+func (r *cassetteRecorder) offline() bool {
+	c := r.cassette()
+	return c != nil && (!c.recording || c.endpoint != "")
+}
+
+func (r *cassetteRecorder) cassette() *cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// providerConfigure configures the provider with the recorder transport, the replayed requests
+// are never signed with real credentials so the fake one is used when there is none.
+// The state waiters poll every few milliseconds when there is no real api to wait for.
+func (r *cassetteRecorder) providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config, err := expandProviderConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	c := r.cassette()
+	if c != nil && c.endpoint != "" {
+		config.AccessKey, config.SecretKey, config.Region = fakeApiAccessKey, fakeApiSecretKey, fakeApiRegion
+		config.Endpoints = make(map[string]string)
+		for _, service := range fakeApiServices {
+			config.Endpoints[service] = c.endpoint
+		}
+	} else if c != nil && !c.recording && config.AccessKey == "" {
+		config.AccessKey, config.SecretKey = "AKLTcassette", "cassette"
+	}
+	config.WrapTransport = func(next http.RoundTripper) http.RoundTripper {
+		return &cassetteTransport{recorder: r, next: next}
+	}
+	client, err := config.Client()
+	if err == nil && r.offline() {
+		client.waiterTiming = &fakeApiWaiterTiming
+	}
+	return client, err
+}
+
+type cassetteTransport struct {
+	recorder *cassetteRecorder
+	next     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c := t.recorder.cassette()
+	if c == nil {
+		return t.next.RoundTrip(r)
+	}
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+		_ = r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	req := newCassetteRequest(r, body)
+	if !c.recording {
+		interaction, err := c.replay(req)
+		if err != nil {
+			return nil, err
+		}
+		return interaction.Response.httpResponse(r), nil
+	}
+
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	c.record(&cassetteInteraction{
+		Request: req,
+		Response: cassetteResponse{
+			Status:      resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        scrubCassetteBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+func newCassetteRequest(r *http.Request, body []byte) cassetteRequest {
+	params := url.Values{}
+	for k, v := range r.URL.Query() {
+		params[k] = v
+	}
+	req := cassetteRequest{
+		Service: cassetteService(r),
+		Method:  r.Method,
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, _ := url.ParseQuery(string(body))
+		for k, v := range form {
+			if _, ok := params[k]; !ok {
+				params[k] = v
+			}
+		}
+	} else if len(body) > 0 {
+		req.Body = scrubCassetteBody(body)
+	}
+	req.Action = params.Get("Action")
+	if req.Action == "" {
+		req.Action = r.URL.Path
+	}
+	for k := range params {
+		if isCassetteSensitiveKey(k) {
+			params[k] = []string{cassetteRedacted}
+		}
+	}
+	req.Params = scrubCassetteCredentials(normalizeCassetteParams(params).Encode())
+	return req
+}
+
+// normalizeCassetteParams sorts the values of each indexed parameter such as VpcId.1 and VpcId.2,
+// the provider builds some of them from sets and maps, so their order changes between the runs
+func normalizeCassetteParams(params url.Values) url.Values {
+	indexed := make(map[string][]string)
+	for k, v := range params {
+		i := strings.LastIndex(k, ".")
+		if i < 0 || len(v) != 1 {
+			continue
+		}
+		if _, err := strconv.Atoi(k[i+1:]); err != nil {
+			continue
+		}
+		indexed[k[:i]] = append(indexed[k[:i]], v[0])
+		delete(params, k)
+	}
+	for prefix, values := range indexed {
+		sort.Strings(values)
+		for i, v := range values {
+			params.Set(fmt.Sprintf("%s.%d", prefix, i+1), v)
+		}
+	}
+	return params
+}
+
+// cassetteService returns the service of signature credential scope, or the host for the requests signed otherwise
+func cassetteService(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if i := strings.Index(auth, "Credential="); i >= 0 {
+		scope := strings.Split(strings.SplitN(auth[i+len("Credential="):], ",", 2)[0], "/")
+		if len(scope) == 5 {
+			return scope[3]
+		}
+	}
+	return r.URL.Host
+}
+
+func (r cassetteResponse) httpResponse(req *http.Request) *http.Response {
+	header := http.Header{}
+	if r.ContentType != "" {
+		header.Set("Content-Type", r.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+func (c *cassette) record(interaction *cassetteInteraction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+}
+
+// replay returns the first unused interaction with the same request, the requests are compared by their service,
+// action and normalized parameters. A request without such interaction is unmatched and fails the test,
+// so the polling of the replay must send the same requests as the recording.
+func (c *cassette) replay(req cassetteRequest) (*cassetteInteraction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, interaction := range c.Interactions {
+		if !c.used[i] && interaction.Request == req {
+			c.used[i] = true
+			return interaction, nil
+		}
+	}
+	c.unmatched = append(c.unmatched, req)
+	return nil, fmt.Errorf("cassette %s has no unused interaction of %s %s with %s", c.path, req.Service, req.Action, req.Params)
+}
+
+func (c *cassette) unmatchedRequests() []cassetteRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.unmatched
+}
+
+func loadCassette(path string) (*cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &cassette{path: path}
+	if err = yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("error on reading cassette %s, %s", path, err)
+	}
+	c.used = make([]bool, len(c.Interactions))
+	return c, nil
+}
+
+func (c *cassette) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, data, 0644)
+}
+
+func isCassetteSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range cassetteSensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubCassetteBody redacts the sensitive fields of json body, the body which is not json is kept as it is
+func scrubCassetteBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return scrubCassetteCredentials(string(body))
+	}
+	data, err := json.Marshal(scrubCassetteValue(v))
+	if err != nil {
+		return scrubCassetteCredentials(string(body))
+	}
+	return scrubCassetteCredentials(string(data))
+}
+
+func scrubCassetteValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k := range value {
+			if isCassetteSensitiveKey(k) {
+				value[k] = cassetteRedacted
+			} else {
+				value[k] = scrubCassetteValue(value[k])
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = scrubCassetteValue(value[i])
+		}
+	}
+	return v
+}
+
+// scrubCassetteCredentials redacts the credentials of environment which appear in s
+func scrubCassetteCredentials(s string) string {
+	for _, env := range []string{"KSYUN_ACCESS_KEY", "KSYUN_SECRET_KEY"} {
+		if v := os.Getenv(env); v != "" {
+			s = strings.Replace(s, v, cassetteRedacted, -1)
+		}
+	}
+	return s
+}
+
+func TestCassetteRecorder_recordAndReplay(t *testing.T) {
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	recorder := &cassetteRecorder{}
+	provider := Provider().(*schema.Provider)
+	provider.ConfigureFunc = recorder.providerConfigure
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "vpc.yaml")
+
+	testCase := func() resource.TestCase {
+		return resource.TestCase{
+			Providers: map[string]terraform.ResourceProvider{"ksyun": provider},
+			Steps: []resource.TestStep{
+				{
+					Config: api.config(testAccVPCConfigTags),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttrSet("ksyun_vpc.foo", "id"),
+						resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.env", "test"),
+					),
+				},
+			},
+		}
+	}
+	t.Run("record", func(t *testing.T) {
+		defer recorder.use(t, &cassette{path: path, recording: true})()
+		resource.UnitTest(t, testCase())
+	})
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), fakeApiAccessKey) || strings.Contains(string(data), "Authorization") {
+		t.Fatalf("cassette is not scrubbed:\n%s", data)
+	}
+
+	recorded := len(api.requests)
+	t.Run("replay", func(t *testing.T) {
+		c, err := loadCassette(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer recorder.use(t, c)()
+		resource.UnitTest(t, testCase())
+	})
+	if len(api.requests) != recorded {
+		t.Errorf("replay sent %d requests to the api", len(api.requests)-recorded)
+	}
+}
+
+func TestScrubCassetteBody(t *testing.T) {
+	body := scrubCassetteBody([]byte(`{"AccessKey":{"AccessKeyId":"AKLT1","SecretAccessKey":"s1"},"Instances":[{"InstancePassword":"p1","InstanceName":"n1","Count":1}]}`))
+	expected := `{"AccessKey":{"AccessKeyId":"REDACTED","SecretAccessKey":"REDACTED"},"Instances":[{"Count":1,"InstanceName":"n1","InstancePassword":"REDACTED"}]}`
+	if body != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+	if body = scrubCassetteBody([]byte("<xml/>")); body != "<xml/>" {
+		t.Errorf("expected the body which is not json is kept, got %s", body)
+	}
+}
+
+func TestCassetteReplay(t *testing.T) {
+	request := func(params string) cassetteRequest {
+		return cassetteRequest{Service: "vpc", Action: "DescribeVpcs", Method: "GET", Params: params}
+	}
+	c := &cassette{
+		path: "vpc.yaml",
+		Interactions: []*cassetteInteraction{
+			{Request: request("VpcId.1=vpc-1"), Response: cassetteResponse{Status: 200, Body: "1"}},
+			{Request: request("VpcId.1=vpc-1"), Response: cassetteResponse{Status: 200, Body: "2"}},
+		},
+		used: make([]bool, 2),
+	}
+	for _, expected := range []string{"1", "2"} {
+		interaction, err := c.replay(request("VpcId.1=vpc-1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if interaction.Response.Body != expected {
+			t.Errorf("expected the response %s, got %s", expected, interaction.Response.Body)
+		}
+	}
+	if _, err := c.replay(request("VpcId.1=vpc-1")); err == nil {
+		t.Error("expected the used interactions are not replayed again")
+	}
+	if _, err := c.replay(request("VpcId.1=vpc-2")); err == nil {
+		t.Error("expected the request with other params is not matched")
+	}
+	if len(c.unmatchedRequests()) != 2 {
+		t.Errorf("expected 2 unmatched requests, got %v", c.unmatchedRequests())
+	}
+}
+
+func TestNormalizeCassetteParams(t *testing.T) {
+	params := url.Values{
+		"Action":           {"DescribeSubnets"},
+		"SubnetId.1":       {"subnet-2"},
+		"SubnetId.2":       {"subnet-1"},
+		"Filter.1.Name":    {"vpc-id"},
+		"Filter.1.Value.1": {"vpc-2"},
+		"Filter.1.Value.2": {"vpc-1"},
+	}
+	expected := "Action=DescribeSubnets&Filter.1.Name=vpc-id&Filter.1.Value.1=vpc-1&Filter.1.Value.2=vpc-2&SubnetId.1=subnet-1&SubnetId.2=subnet-2"
+	if encoded := normalizeCassetteParams(params).Encode(); encoded != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+}
@@ -2,6 +2,7 @@ package ksyun
 
 import (
 	"github.com/KscSDK/ksc-sdk-go/service/bws"
+	"github.com/KscSDK/ksc-sdk-go/service/dedicated"
 	"github.com/KscSDK/ksc-sdk-go/service/ebs"
 	"github.com/KscSDK/ksc-sdk-go/service/eip"
 	"github.com/KscSDK/ksc-sdk-go/service/epc"
@@ -19,6 +20,8 @@ import (
 	"github.com/KscSDK/ksc-sdk-go/service/tag"
 	"github.com/KscSDK/ksc-sdk-go/service/tagv2"
 	"github.com/KscSDK/ksc-sdk-go/service/vpc"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/ks3sdklib/aws-sdk-go/service/s3"
 )
 
@@ -44,4 +47,74 @@ type KsyunClient struct {
 	bwsconn       *bws.Bws             `json:"bwsconn,omitempty"`
 	tagconn       *tagv2.Tagv2         `json:"tagconn,omitempty"`
 	tagv1conn     *tag.Tag             `json:"tagv1conn,omitempty"`
+	dedicatedconn *dedicated.Dedicated
+	// kecRegionConn returns the kec connection of another region, such as the regions of the image copies
+	kecRegionConn func(region string) *kec.Kec
+
+	defaultTags  map[string]string
+	ignoreTags   *ignoreTagsConfig
+	metrics      *apiMetrics
+	waiterTiming *stateWaiterTiming
+
+	describeBatcher *describeBatcher
+}
+
+// serviceHandlers returns the request handlers of ksc connections grouped by service name
+func (client *KsyunClient) serviceHandlers() map[string][]*request.Handlers {
+	return map[string][]*request.Handlers{
+		"vpc":       {&client.vpcconn.Handlers},
+		"eip":       {&client.eipconn.Handlers},
+		"slb":       {&client.slbconn.Handlers},
+		"kec":       {&client.kecconn.Handlers, &client.dedicatedconn.Handlers},
+		"sqlserver": {&client.sqlserverconn.Handlers},
+		"krds":      {&client.krdsconn.Handlers},
+		"kcm":       {&client.kcmconn.Handlers},
+		"sks":       {&client.sksconn.Handlers},
+		"kcs":       {&client.kcsv1conn.Handlers, &client.kcsv2conn.Handlers},
+		"epc":       {&client.epcconn.Handlers},
+		"ebs":       {&client.ebsconn.Handlers},
+		"mongodb":   {&client.mongodbconn.Handlers},
+		"iam":       {&client.iamconn.Handlers},
+		"rabbitmq":  {&client.rabbitmqconn.Handlers},
+		"bws":       {&client.bwsconn.Handlers},
+		"tag":       {&client.tagconn.Handlers, &client.tagv1conn.Handlers},
+	}
+}
+
+// serviceConns returns the ksc connections grouped by the same service names as serviceHandlers,
+// the actions of a service are the methods of its connections
+func (client *KsyunClient) serviceConns() map[string][]interface{} {
+	return map[string][]interface{}{
+		"vpc":       {client.vpcconn},
+		"eip":       {client.eipconn},
+		"slb":       {client.slbconn},
+		"kec":       {client.kecconn, client.dedicatedconn},
+		"sqlserver": {client.sqlserverconn},
+		"krds":      {client.krdsconn},
+		"kcm":       {client.kcmconn},
+		"sks":       {client.sksconn},
+		"kcs":       {client.kcsv1conn, client.kcsv2conn},
+		"epc":       {client.epcconn},
+		"ebs":       {client.ebsconn},
+		"mongodb":   {client.mongodbconn},
+		"iam":       {client.iamconn},
+		"rabbitmq":  {client.rabbitmqconn},
+		"bws":       {client.bwsconn},
+		"tag":       {client.tagconn, client.tagv1conn},
+	}
+}
+
+// callSdkAction calls an action which the ksc sdk does not generate a method for yet,
+// the request is built and sent by the client of the service the same way as the generated methods
+func callSdkAction(c *client.Client, action string, input *map[string]interface{}) (*map[string]interface{}, error) {
+	if input == nil {
+		input = &map[string]interface{}{}
+	}
+	output := &map[string]interface{}{}
+	req := c.NewRequest(&request.Operation{
+		Name:       action,
+		HTTPMethod: "GET",
+		HTTPPath:   "/",
+	}, input, output)
+	return output, req.Send()
 }
@@ -415,6 +415,7 @@ func ConvertFilterStructPrefix(v interface{}, req *map[string]interface{}, prex
 	}
 */
 func dataSourceKscSave(d *schema.ResourceData, dataKey string, ids []string, datas []map[string]interface{}) error {
+	ids, datas = filterDataSourceResults(d, ids, datas)
 
 	d.SetId(hashStringArray(ids))
 	if err := d.Set("total_count", len(datas)); err != nil {
@@ -424,7 +425,7 @@ func dataSourceKscSave(d *schema.ResourceData, dataKey string, ids []string, dat
 		return fmt.Errorf("error set datas %v :%v", datas, err)
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
-		return writeToFile(outputFile.(string), datas)
+		return writeDataSourceOutput(d, dataKey, outputFile.(string), datas)
 	}
 
 	return nil
@@ -440,7 +441,7 @@ func dataSourceKscSaveSlice(d *schema.ResourceData, dataKey string, ids []string
 		return fmt.Errorf("error set datas %v :%v", datas, err)
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
-		return writeToFile(outputFile.(string), datas)
+		return writeDataSourceOutput(d, dataKey, outputFile.(string), datas)
 	}
 
 	return nil
@@ -464,13 +465,14 @@ func dataSourceDbSave(d *schema.ResourceData, dataKey string, ids []string, data
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
 		logger.DebugInfo(" output file name : %+v", outputFile.(string)+"_"+d.Id())
-		return writeToFile(outputFile.(string)+"_"+d.Id(), datas)
+		return writeDataSourceOutput(d, dataKey, outputFile.(string)+"_"+d.Id(), datas)
 	} else {
 		return fmt.Errorf(" output file error,  %+v", outputFile)
 	}
 }
 
 func dataDbSave(d *schema.ResourceData, dataKey string, ids []string, datas []map[string]interface{}) error {
+	ids, datas = filterDataSourceResults(d, ids, datas)
 	if len(ids) == 1 {
 		d.SetId(ids[0])
 	} else {
@@ -488,7 +490,7 @@ func dataDbSave(d *schema.ResourceData, dataKey string, ids []string, datas []ma
 	}
 	if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
 		logger.DebugInfo(" ------------ %+v", outputFile)
-		return writeToFile(outputFile.(string)+"_"+"data", datas)
+		return writeDataSourceOutput(d, dataKey, outputFile.(string)+"_"+"data", datas)
 	} else {
 		return fmt.Errorf(" !!! %+v", outputFile)
 	}
@@ -1,9 +1,14 @@
 package ksyun
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
 	"github.com/KscSDK/ksc-sdk-go/ksc"
 	"github.com/KscSDK/ksc-sdk-go/ksc/utils"
 	"github.com/KscSDK/ksc-sdk-go/service/bws"
+	"github.com/KscSDK/ksc-sdk-go/service/dedicated"
 	"github.com/KscSDK/ksc-sdk-go/service/ebs"
 	"github.com/KscSDK/ksc-sdk-go/service/eip"
 	"github.com/KscSDK/ksc-sdk-go/service/epc"
@@ -21,9 +26,19 @@ import (
 	"github.com/KscSDK/ksc-sdk-go/service/tag"
 	"github.com/KscSDK/ksc-sdk-go/service/tagv2"
 	"github.com/KscSDK/ksc-sdk-go/service/vpc"
+	sdkaws "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/ks3sdklib/aws-sdk-go/aws"
 	"github.com/ks3sdklib/aws-sdk-go/aws/credentials"
 	"github.com/ks3sdklib/aws-sdk-go/service/s3"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
 )
 
 // Config is the configuration of ksyun meta data
@@ -35,52 +50,288 @@ type Config struct {
 	Domain        string
 	DryRun        bool
 	IgnoreService bool
+	CaBundleFile  string
+	HttpProxy     string
+	TlsSkipVerify bool
+	Endpoints     map[string]string
+	SecurityToken string
+	AssumeRole    *AssumeRoleConfig
+
+	SharedCredentialsFile string
+	Profile               string
+
+	MaxRetries   int
+	RetryBackoff time.Duration
+	RateLimits   map[string]int
+	DefaultTags  map[string]string
+
+	IgnoreTagKeys        []string
+	IgnoreTagKeyPrefixes []string
+
+	// LogRedactKeys is the sensitive field names redacted from logs besides logger.DefaultRedactKeys, merged with the keys of the other configurations
+	LogRedactKeys []string
+
+	// WrapTransport wraps the http transport of all the sdk clients, the tests use it to record and replay api calls
+	WrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // Client will returns a client with connections for all product
 func (c *Config) Client() (*KsyunClient, error) {
 	var client KsyunClient
+	err := c.loadSharedCredentials()
+	if err != nil {
+		return nil, err
+	}
 	//init ksc client info
 	client.region = c.Region
-	cli := ksc.NewClient(c.AccessKey, c.SecretKey)
+	httpClient, err := c.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	kscCredentials, err := c.credentials(httpClient)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := session.NewSession(&sdkaws.Config{
+		Credentials: kscCredentials,
+		HTTPClient:  httpClient,
+		// the retryer replaces the default retryer of the sdk, the retries are decided only by apiRetryer
+		Retryer: apiRetryer{
+			maxRetries: c.MaxRetries,
+			backoff:    c.RetryBackoff,
+		},
+		EnforceShouldRetryCheck: sdkaws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the transport is wrapped after creating the sessions, which load the custom ca bundle into *http.Transport
+	if c.WrapTransport != nil {
+		httpClient.Transport = c.WrapTransport(httpClient.Transport)
+	}
 	cfg := &ksc.Config{
 		Region: &c.Region,
 	}
-	url := &utils.UrlInfo{
-		UseSSL:                      false,
-		Locate:                      false,
-		CustomerDomain:              c.Domain,
-		CustomerDomainIgnoreService: c.IgnoreService,
-	}
 	client.dryRun = c.DryRun
-	client.vpcconn = vpc.SdkNew(cli, cfg, url)
-	client.eipconn = eip.SdkNew(cli, cfg, url)
-	client.slbconn = slb.SdkNew(cli, cfg, url)
-	client.kecconn = kec.SdkNew(cli, cfg, url)
-	client.sqlserverconn = sqlserver.SdkNew(cli, cfg, url)
-	client.krdsconn = krds.SdkNew(cli, cfg, url)
-	client.kcmconn = kcm.SdkNew(cli, cfg, url)
-	client.sksconn = sks.SdkNew(cli, cfg, url)
-	client.kcsv1conn = kcsv1.SdkNew(cli, cfg, url)
-	client.kcsv2conn = kcsv2.SdkNew(cli, cfg, url)
-	client.epcconn = epc.SdkNew(cli, cfg, url)
-	client.ebsconn = ebs.SdkNew(cli, cfg, url)
-	client.mongodbconn = mongodb.SdkNew(cli, cfg, url)
-	client.iamconn = iam.SdkNew(cli, cfg, url)
-	client.rabbitmqconn = rabbitmq.SdkNew(cli, cfg, url)
-	client.bwsconn = bws.SdkNew(cli, cfg, url)
-	client.tagconn = tagv2.SdkNew(cli, cfg, url)
-	client.tagv1conn = tag.SdkNew(cli, cfg, url)
-
-	credentials := credentials.NewStaticCredentials(c.AccessKey, c.SecretKey, "")
+	client.describeBatcher = newDescribeBatcher(defaultDescribeBatchWindow)
+	client.defaultTags = c.DefaultTags
+	client.ignoreTags = &ignoreTagsConfig{
+		keys:        c.IgnoreTagKeys,
+		keyPrefixes: c.IgnoreTagKeyPrefixes,
+	}
+	client.vpcconn = vpc.SdkNew(cli, cfg, c.urlInfo("vpc"))
+	client.eipconn = eip.SdkNew(cli, cfg, c.urlInfo("eip"))
+	client.slbconn = slb.SdkNew(cli, cfg, c.urlInfo("slb"))
+	client.kecconn = kec.SdkNew(cli, cfg, c.urlInfo("kec"))
+	client.sqlserverconn = sqlserver.SdkNew(cli, cfg, c.urlInfo("sqlserver"))
+	client.krdsconn = krds.SdkNew(cli, cfg, c.urlInfo("krds"))
+	client.kcmconn = kcm.SdkNew(cli, cfg, c.urlInfo("kcm"))
+	client.sksconn = sks.SdkNew(cli, cfg, c.urlInfo("sks"))
+	client.kcsv1conn = kcsv1.SdkNew(cli, cfg, c.urlInfo("kcs"))
+	client.kcsv2conn = kcsv2.SdkNew(cli, cfg, c.urlInfo("kcs"))
+	client.epcconn = epc.SdkNew(cli, cfg, c.urlInfo("epc"))
+	client.ebsconn = ebs.SdkNew(cli, cfg, c.urlInfo("ebs"))
+	client.mongodbconn = mongodb.SdkNew(cli, cfg, c.urlInfo("mongodb"))
+	client.iamconn = iam.SdkNew(cli, cfg, c.urlInfo("iam"))
+	client.rabbitmqconn = rabbitmq.SdkNew(cli, cfg, c.urlInfo("rabbitmq"))
+	client.bwsconn = bws.SdkNew(cli, cfg, c.urlInfo("bws"))
+	client.tagconn = tagv2.SdkNew(cli, cfg, c.urlInfo("tag"))
+	client.tagv1conn = tag.SdkNew(cli, cfg, c.urlInfo("tag"))
+	// the dedicated hosts are kec actions, they share the endpoint of kec
+	client.dedicatedconn = dedicated.SdkNew(cli, cfg, c.urlInfo("kec"))
+
+	ks3Endpoint := c.Region
+	if endpoint, ok := c.Endpoints["ks3"]; ok {
+		ks3Endpoint = endpoint
+	}
 	client.ks3conn = s3.New(&aws.Config{
-		Region:           "BEIJING",
-		Credentials:      credentials,
-		Endpoint:         c.Region,
-		DisableSSL:       true,
+		Region: "BEIJING",
+		Credentials: credentials.NewCredentials(&ks3CredentialsProvider{
+			credentials: kscCredentials,
+		}),
+		Endpoint:         ks3Endpoint,
+		DisableSSL:       c.Insecure,
+		HTTPClient:       httpClient,
 		LogLevel:         1,
 		S3ForcePathStyle: true,
 		LogHTTPBody:      true,
 	})
+	c.applyServiceErrors(&client)
+	c.applyRateLimits(&client)
+	c.applyApiCallLog(&client)
+	c.applyApiMetrics(&client)
+	// the connections of other regions share the handlers of kecconn, which are complete after applying all of them
+	client.kecRegionConn = func(region string) *kec.Kec {
+		conn := kec.SdkNew(cli, &ksc.Config{Region: &region}, c.urlInfo("kec"))
+		conn.Handlers = client.kecconn.Handlers.Copy()
+		return conn
+	}
 	return &client, nil
 }
+
+// applyServiceErrors binds the request failures to the service of the connection, so the error codes are classified per service
+func (c *Config) applyServiceErrors(client *KsyunClient) {
+	for service, handlers := range client.serviceHandlers() {
+		service := service
+		for _, h := range handlers {
+			h.UnmarshalError.PushBack(func(r *request.Request) {
+				r.Error = newServiceRequestFailure(service, r.Error)
+			})
+		}
+	}
+}
+
+// applyApiCallLog logs every api call as a json object when it completes
+func (c *Config) applyApiCallLog(client *KsyunClient) {
+	logger.AddRedactKeys(c.LogRedactKeys)
+	for service, handlers := range client.serviceHandlers() {
+		service := service
+		for _, h := range handlers {
+			h.Complete.PushBack(func(r *request.Request) {
+				logger.ApiCall(newApiCallEntry(service, r))
+			})
+		}
+	}
+}
+
+// applyApiMetrics records the count, errors, retries and latency of every api call to the metrics of the provider process
+func (c *Config) applyApiMetrics(client *KsyunClient) {
+	client.metrics = defaultApiMetrics
+	for service, handlers := range client.serviceHandlers() {
+		service := service
+		for _, h := range handlers {
+			h.Complete.PushBack(func(r *request.Request) {
+				action := ""
+				if r.Operation != nil {
+					action = r.Operation.Name
+				}
+				client.metrics.observe(service, action, time.Since(r.Time), r.Error != nil, r.RetryCount)
+			})
+		}
+	}
+}
+
+func newApiCallEntry(service string, r *request.Request) logger.ApiCallEntry {
+	entry := logger.ApiCallEntry{
+		Service:   service,
+		Region:    sdkaws.StringValue(r.Config.Region),
+		RequestId: r.RequestID,
+		LatencyMs: time.Since(r.Time).Nanoseconds() / int64(time.Millisecond),
+		Retries:   r.RetryCount,
+		Request:   apiCallParams(r),
+	}
+	if r.Operation != nil {
+		entry.Action = r.Operation.Name
+	}
+	if r.HTTPResponse != nil {
+		entry.StatusCode = r.HTTPResponse.StatusCode
+	}
+	if r.Error != nil {
+		entry.Error = r.Error.Error()
+	} else {
+		entry.Response = r.Data
+		if data, ok := r.Data.(*map[string]interface{}); ok && data != nil && entry.RequestId == "" {
+			entry.RequestId, _ = (*data)["RequestId"].(string)
+		}
+	}
+	return entry
+}
+
+// apiCallParams returns the params of request, the json body is decoded because the sdk drops the params after building it
+func apiCallParams(r *request.Request) interface{} {
+	if r.Params != nil || r.Body == nil {
+		return r.Params
+	}
+	if _, err := r.Body.Seek(0, io.SeekStart); err != nil {
+		return nil
+	}
+	var params map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		return nil
+	}
+	return params
+}
+
+// applyRateLimits makes every request of the service wait for the token of its rate limiter before sending
+func (c *Config) applyRateLimits(client *KsyunClient) {
+	for service, handlers := range client.serviceHandlers() {
+		if qps, ok := c.RateLimits[service]; ok && qps > 0 {
+			limiter := newRateLimiter(qps)
+			for _, h := range handlers {
+				h.Send.PushFront(func(r *request.Request) {
+					limiter.Wait()
+				})
+			}
+		}
+	}
+	if qps, ok := c.RateLimits["ks3"]; ok && qps > 0 {
+		limiter := newRateLimiter(qps)
+		client.ks3conn.Handlers.Send.PushFront(func(r *aws.Request) {
+			limiter.Wait()
+		})
+	}
+}
+
+// urlInfo returns the url info of the service connection,
+// the endpoint in endpoints block will take precedence over domain
+func (c *Config) urlInfo(service string) *utils.UrlInfo {
+	info := &utils.UrlInfo{
+		UseSSL:                      !c.Insecure,
+		Locate:                      false,
+		CustomerDomain:              c.Domain,
+		CustomerDomainIgnoreService: c.IgnoreService,
+	}
+	if endpoint, ok := c.Endpoints[service]; ok {
+		info.CustomerDomain, info.UseSSL = parseEndpoint(endpoint, info.UseSSL)
+		info.CustomerDomainIgnoreService = true
+	}
+	return info
+}
+
+// httpClient builds the http client shared by all product connections,
+// it applies the custom ca bundle, tls verification and proxy settings
+func (c *Config) httpClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.TlsSkipVerify,
+	}
+	if c.CaBundleFile != "" {
+		caPath, err := getAbsPath(c.CaBundleFile)
+		if err != nil {
+			return nil, err
+		}
+		pem, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("error on reading ca bundle file %q, %s", c.CaBundleFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca bundle file %q does not contain any valid certificate", c.CaBundleFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// the transport has the settings of http.DefaultTransport, it is built by hand since Transport.Clone needs go 1.13
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			DualStack: true,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       tlsConfig,
+	}
+	if c.HttpProxy != "" {
+		proxy, err := url.Parse(c.HttpProxy)
+		if err != nil {
+			return nil, fmt.Errorf("error on parsing http proxy %q, %s", c.HttpProxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	return &http.Client{
+		Transport: transport,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package ksyun
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestConfigHttpClient(t *testing.T) {
+	a := assert.New(t)
+
+	c := Config{
+		HttpProxy:     "http://127.0.0.1:3128",
+		TlsSkipVerify: true,
+	}
+	client, err := c.httpClient()
+	a.Nil(err)
+	transport := client.Transport.(*http.Transport)
+	a.True(transport.TLSClientConfig.InsecureSkipVerify)
+	req, _ := http.NewRequest("GET", "https://vpc.api.ksyun.com", nil)
+	proxy, err := transport.Proxy(req)
+	a.Nil(err)
+	a.Equal("127.0.0.1:3128", proxy.Host)
+
+	f, err := ioutil.TempFile("", "ca_bundle")
+	a.Nil(err)
+	defer os.Remove(f.Name())
+	_, _ = f.WriteString("not a certificate")
+	_ = f.Close()
+	c = Config{
+		CaBundleFile: f.Name(),
+	}
+	_, err = c.httpClient()
+	a.NotNil(err)
+}
+
+func TestConfigUrlInfo(t *testing.T) {
+	a := assert.New(t)
+
+	c := Config{
+		Domain: "inner.example.com",
+		Endpoints: map[string]string{
+			"vpc": "http://127.0.0.1:8080",
+			"kec": "kec.private.example.com",
+		},
+	}
+	vpcInfo := c.urlInfo("vpc")
+	a.False(vpcInfo.UseSSL)
+	a.True(vpcInfo.CustomerDomainIgnoreService)
+	a.Equal("127.0.0.1:8080", vpcInfo.CustomerDomain)
+
+	kecInfo := c.urlInfo("kec")
+	a.True(kecInfo.UseSSL)
+	a.Equal("kec.private.example.com", kecInfo.CustomerDomain)
+
+	eipInfo := c.urlInfo("eip")
+	a.True(eipInfo.UseSSL)
+	a.False(eipInfo.CustomerDomainIgnoreService)
+	a.Equal("inner.example.com", eipInfo.CustomerDomain)
+}
+
+func TestConfigApiCallLog(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	c := Config{
+		AccessKey:     fakeApiAccessKey,
+		SecretKey:     fakeApiSecretKey,
+		Region:        fakeApiRegion,
+		Endpoints:     map[string]string{"vpc": api.server.URL},
+		LogRedactKeys: []string{"TfLogRedactTest"},
+	}
+	client, err := c.Client()
+	a.Nil(err)
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	_, err = client.vpcconn.CreateVpc(&map[string]interface{}{
+		"VpcName":         "tf-vpc",
+		"CidrBlock":       "10.0.0.0/16",
+		"TfLogRedactTest": "tf-redact-value",
+	})
+	a.Nil(err)
+	output := buf.String()
+	a.Contains(output, `"action":"CreateVpc","service":"vpc","region":"cn-beijing-6"`)
+	a.Contains(output, `"status_code":200`)
+	a.Contains(output, `"TfLogRedactTest":"******"`)
+	a.NotContains(output, "tf-redact-value")
+	a.Contains(output, `"VpcName":"tf-vpc"`)
+}
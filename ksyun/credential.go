@@ -0,0 +1,275 @@
+package ksyun
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/KscSDK/ksc-sdk-go/ksc"
+	"github.com/KscSDK/ksc-sdk-go/service/sts"
+	sdkaws "github.com/aws/aws-sdk-go/aws"
+	sdkcredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	ks3credentials "github.com/ks3sdklib/aws-sdk-go/aws/credentials"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	assumeRoleProviderName = "KsyunAssumeRoleProvider"
+	// assumeRoleExpiryWindow makes the credentials refresh before they are really expired
+	assumeRoleExpiryWindow = 5 * time.Minute
+
+	defaultSharedCredentialsFile = "~/.ksyun/config"
+	defaultProfile               = "default"
+)
+
+// AssumeRoleConfig is the configuration of assume_role block
+type AssumeRoleConfig struct {
+	RoleKrn         string
+	SessionName     string
+	DurationSeconds int
+	Policy          string
+}
+
+// assumeRoleProvider retrieves temporary credentials from sts and refreshes them before expiration
+type assumeRoleProvider struct {
+	sdkcredentials.Expiry
+	conn   *sts.Sts
+	config *AssumeRoleConfig
+}
+
+func (p *assumeRoleProvider) Retrieve() (sdkcredentials.Value, error) {
+	var value sdkcredentials.Value
+	req := map[string]interface{}{
+		"RoleKrn":         p.config.RoleKrn,
+		"RoleSessionName": p.config.SessionName,
+	}
+	if p.config.DurationSeconds > 0 {
+		req["DurationSeconds"] = p.config.DurationSeconds
+	}
+	if p.config.Policy != "" {
+		req["Policy"] = p.config.Policy
+	}
+	action := "AssumeRole"
+	logger.Debug(logger.ReqFormat, action, req)
+	resp, err := p.conn.AssumeRole(&req)
+	if err != nil {
+		return value, fmt.Errorf("error on assuming role %q, %s", p.config.RoleKrn, err)
+	}
+	result, err := getSdkValue("AssumeRoleResult.Credentials", *resp)
+	if err != nil {
+		return value, err
+	}
+	credentials, ok := result.(map[string]interface{})
+	if !ok {
+		return value, fmt.Errorf("error on assuming role %q, credentials not found in response", p.config.RoleKrn)
+	}
+	value = sdkcredentials.Value{
+		AccessKeyID:     stringValue(credentials["AccessKeyId"]),
+		SecretAccessKey: stringValue(credentials["AccessKeySecret"]),
+		SessionToken:    stringValue(credentials["SecurityToken"]),
+		ProviderName:    assumeRoleProviderName,
+	}
+	if value.SecretAccessKey == "" {
+		value.SecretAccessKey = stringValue(credentials["SecretAccessKey"])
+	}
+	if !value.HasKeys() {
+		return value, fmt.Errorf("error on assuming role %q, access key not found in response", p.config.RoleKrn)
+	}
+
+	expiration := time.Now().Add(time.Duration(p.config.DurationSeconds) * time.Second)
+	if v := stringValue(credentials["Expiration"]); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			expiration = t
+		}
+	}
+	p.SetExpiration(expiration, assumeRoleExpiryWindow)
+	return value, nil
+}
+
+// ks3CredentialsProvider shares the ksc credentials with the ks3 client
+type ks3CredentialsProvider struct {
+	credentials *sdkcredentials.Credentials
+}
+
+func (p *ks3CredentialsProvider) Retrieve() (ks3credentials.Value, error) {
+	value, err := p.credentials.Get()
+	if err != nil {
+		return ks3credentials.Value{}, err
+	}
+	return ks3credentials.Value{
+		AccessKeyID:     value.AccessKeyID,
+		SecretAccessKey: value.SecretAccessKey,
+		SessionToken:    value.SessionToken,
+	}, nil
+}
+
+func (p *ks3CredentialsProvider) IsExpired() bool {
+	return p.credentials.IsExpired()
+}
+
+// credentials returns the credentials used by all product connections,
+// the static credentials will be exchanged by sts when assume_role is set
+func (c *Config) credentials(httpClient *http.Client) (*sdkcredentials.Credentials, error) {
+	static := sdkcredentials.NewStaticCredentials(c.AccessKey, c.SecretKey, c.SecurityToken)
+	if c.AssumeRole == nil {
+		return static, nil
+	}
+	sess, err := session.NewSession(&sdkaws.Config{
+		Credentials: static,
+		HTTPClient:  httpClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	credentials := sdkcredentials.NewCredentials(&assumeRoleProvider{
+		conn: sts.SdkNew(sess, &ksc.Config{
+			Region: &c.Region,
+		}, c.urlInfo("sts")),
+		config: c.AssumeRole,
+	})
+	// get credentials at once, so wrong role configuration fails on configuring provider
+	if _, err = credentials.Get(); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
+
+func stringValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// loadSharedCredentials fills the empty access key, secret key, region and domain from the profile
+// of shared credentials file, the file can be INI or JSON which compatible with ksyun cli config
+func (c *Config) loadSharedCredentials() error {
+	filePath := c.SharedCredentialsFile
+	explicitFile := filePath != ""
+	if !explicitFile {
+		filePath = defaultSharedCredentialsFile
+	}
+	profileName := c.Profile
+	explicitProfile := profileName != ""
+	if !explicitProfile {
+		profileName = defaultProfile
+	}
+
+	absPath, err := getAbsPath(filePath)
+	if err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		if os.IsNotExist(err) && !explicitFile && !explicitProfile {
+			return nil
+		}
+		return fmt.Errorf("error on reading shared credentials file %q, %s", filePath, err)
+	}
+	profiles, err := parseSharedCredentials(content)
+	if err != nil {
+		return fmt.Errorf("error on parsing shared credentials file %q, %s", filePath, err)
+	}
+	profile, ok := profiles[profileName]
+	if !ok {
+		if !explicitProfile {
+			return nil
+		}
+		return fmt.Errorf("profile %q not exist in shared credentials file %q", profileName, filePath)
+	}
+
+	if c.AccessKey == "" {
+		c.AccessKey = profileValue(profile, "access_key", "ks_access_key_id", "access_key_id")
+	}
+	if c.SecretKey == "" {
+		c.SecretKey = profileValue(profile, "secret_key", "ks_secret_access_key", "access_key_secret", "secret_access_key")
+	}
+	if c.Region == "" {
+		c.Region = profileValue(profile, "region")
+	}
+	if c.Domain == "" {
+		c.Domain = profileValue(profile, "domain")
+	}
+	if c.SecurityToken == "" {
+		c.SecurityToken = profileValue(profile, "security_token")
+	}
+	return nil
+}
+
+func profileValue(profile map[string]string, keys ...string) string {
+	for _, k := range keys {
+		if v, ok := profile[k]; ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseSharedCredentials returns profiles keyed by profile name, the JSON content supports
+// {"profiles":[{"name":"default",...}]} like ksyun cli and {"default":{...}}
+func parseSharedCredentials(content []byte) (map[string]map[string]string, error) {
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "{") {
+		return parseJsonSharedCredentials([]byte(trimmed))
+	}
+	return parseIniSharedCredentials(trimmed)
+}
+
+func parseJsonSharedCredentials(content []byte) (map[string]map[string]string, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	profiles := make(map[string]map[string]string)
+	if list, ok := raw["profiles"].([]interface{}); ok {
+		for _, item := range list {
+			if m, ok := item.(map[string]interface{}); ok {
+				profile := flattenProfile(m)
+				profiles[profile["name"]] = profile
+			}
+		}
+		return profiles, nil
+	}
+	for name, item := range raw {
+		if m, ok := item.(map[string]interface{}); ok {
+			profiles[name] = flattenProfile(m)
+		}
+	}
+	return profiles, nil
+}
+
+func flattenProfile(m map[string]interface{}) map[string]string {
+	profile := make(map[string]string)
+	for k, v := range m {
+		profile[Camel2Hungarian(k)] = fmt.Sprintf("%v", v)
+	}
+	return profile
+}
+
+func parseIniSharedCredentials(content string) (map[string]map[string]string, error) {
+	profiles := make(map[string]map[string]string)
+	var current map[string]string
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			name = strings.TrimSpace(strings.TrimPrefix(name, "profile "))
+			current = make(map[string]string)
+			profiles[name] = current
+			continue
+		}
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 || current == nil {
+			return nil, fmt.Errorf("invalid line %d %q", i+1, line)
+		}
+		current[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.Trim(strings.TrimSpace(kv[1]), `"'`)
+	}
+	return profiles, nil
+}
@@ -0,0 +1,98 @@
+package ksyun
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAssumeRoleCredentials(t *testing.T) {
+	a := assert.New(t)
+	expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"RequestId":"test","AssumeRoleResult":{"Credentials":{"AccessKeyId":"tmp-ak","AccessKeySecret":"tmp-sk","SecurityToken":"tmp-token","Expiration":"%s"}}}`, expiration)
+	}))
+	defer server.Close()
+
+	c := Config{
+		AccessKey: "ak",
+		SecretKey: "sk",
+		Region:    "cn-beijing-6",
+		Endpoints: map[string]string{
+			"sts": server.URL,
+		},
+		AssumeRole: &AssumeRoleConfig{
+			RoleKrn:         "krn:ksc:iam::123456:role/test",
+			SessionName:     "terraform",
+			DurationSeconds: 3600,
+		},
+	}
+	httpClient, err := c.httpClient()
+	a.Nil(err)
+	credentials, err := c.credentials(httpClient)
+	a.Nil(err)
+	value, err := credentials.Get()
+	a.Nil(err)
+	a.Equal("tmp-ak", value.AccessKeyID)
+	a.Equal("tmp-sk", value.SecretAccessKey)
+	a.Equal("tmp-token", value.SessionToken)
+	a.False(credentials.IsExpired())
+	a.Equal([]string{"AssumeRole"}, query["Action"])
+	a.Equal([]string{"krn:ksc:iam::123456:role/test"}, query["RoleKrn"])
+}
+
+func TestLoadSharedCredentials(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "ksyun")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+
+	iniFile := filepath.Join(dir, "config")
+	_ = ioutil.WriteFile(iniFile, []byte(`
+[default]
+access_key = default-ak
+secret_key = default-sk
+region = cn-beijing-6
+
+[profile dev]
+ks_access_key_id = dev-ak
+ks_secret_access_key = dev-sk
+region = cn-shanghai-2
+domain = inner.example.com
+`), 0600)
+
+	c := Config{SharedCredentialsFile: iniFile, Profile: "dev"}
+	a.Nil(c.loadSharedCredentials())
+	a.Equal("dev-ak", c.AccessKey)
+	a.Equal("dev-sk", c.SecretKey)
+	a.Equal("cn-shanghai-2", c.Region)
+	a.Equal("inner.example.com", c.Domain)
+
+	c = Config{SharedCredentialsFile: iniFile, AccessKey: "hcl-ak"}
+	a.Nil(c.loadSharedCredentials())
+	a.Equal("hcl-ak", c.AccessKey)
+	a.Equal("default-sk", c.SecretKey)
+
+	c = Config{SharedCredentialsFile: iniFile, Profile: "prod"}
+	a.NotNil(c.loadSharedCredentials())
+
+	jsonFile := filepath.Join(dir, "config.json")
+	_ = ioutil.WriteFile(jsonFile, []byte(`{"current":"default","profiles":[{"name":"default","AccessKeyId":"json-ak","AccessKeySecret":"json-sk","region":"cn-guangzhou-1"}]}`), 0600)
+	c = Config{SharedCredentialsFile: jsonFile}
+	a.Nil(c.loadSharedCredentials())
+	a.Equal("json-ak", c.AccessKey)
+	a.Equal("json-sk", c.SecretKey)
+	a.Equal("cn-guangzhou-1", c.Region)
+
+	c = Config{SharedCredentialsFile: filepath.Join(dir, "not_exist")}
+	a.NotNil(c.loadSharedCredentials())
+}
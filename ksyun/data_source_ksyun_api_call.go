@@ -0,0 +1,176 @@
+package ksyun
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// apiCallReadOnlyPrefixes is the prefixes of the actions ksyun_api_call is allowed to call
+var apiCallReadOnlyPrefixes = []string{"Describe", "List", "Get"}
+
+func dataSourceKsyunApiCall() *schema.Resource {
+	var services []string
+	for service := range (&KsyunClient{}).serviceConns() {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return &schema.Resource{
+		Read: dataSourceKsyunApiCallRead,
+		Schema: map[string]*schema.Schema{
+			"service": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(services, false),
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateApiCallAction,
+			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"response": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"response_map": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func validateApiCallAction(v interface{}, k string) (ws []string, errors []error) {
+	if !isReadOnlyAction(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q must be a read only action starting with %s, got %q",
+			k, strings.Join(apiCallReadOnlyPrefixes, ", "), v))
+	}
+	return
+}
+
+func isReadOnlyAction(action string) bool {
+	for _, prefix := range apiCallReadOnlyPrefixes {
+		if strings.HasPrefix(action, prefix) && len(action) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func dataSourceKsyunApiCallRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*KsyunClient)
+	service := d.Get("service").(string)
+	action := d.Get("action").(string)
+	req := make(map[string]interface{})
+	for k, v := range d.Get("parameters").(map[string]interface{}) {
+		req[k] = v
+	}
+
+	resp, err := callReadOnlyAction(client, service, action, req)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("error on marshaling the response of %s %s, %s", service, action, err)
+	}
+	if err = d.Set("response", string(body)); err != nil {
+		return err
+	}
+	flattened := make(map[string]interface{})
+	flattenApiResponse("", resp, flattened)
+	if err = d.Set("response_map", flattened); err != nil {
+		return err
+	}
+
+	ids := []string{service, action}
+	for k, v := range req {
+		ids = append(ids, fmt.Sprintf("%s=%v", k, v))
+	}
+	sort.Strings(ids[2:])
+	d.SetId(hashStringArray(ids))
+	return nil
+}
+
+// callReadOnlyAction calls action by the method of the same name of the service connection,
+// the action must be read only so the data source never changes any resource
+func callReadOnlyAction(client *KsyunClient, service, action string, req map[string]interface{}) (map[string]interface{}, error) {
+	if !isReadOnlyAction(action) {
+		return nil, fmt.Errorf("action %s of %s is not read only, only the actions starting with %s are allowed",
+			action, service, strings.Join(apiCallReadOnlyPrefixes, ", "))
+	}
+	var (
+		conns []interface{}
+		call  func(*map[string]interface{}) (*map[string]interface{}, error)
+	)
+	for _, conn := range client.serviceConns()[service] {
+		if !reflect.ValueOf(conn).IsNil() {
+			conns = append(conns, conn)
+		}
+	}
+	if len(conns) == 0 {
+		return nil, fmt.Errorf("service %s is not supported", service)
+	}
+	// the connections of a service, such as kcsv1 and kcsv2 of kcs, do not share any read only action
+	for _, conn := range conns {
+		if method := reflect.ValueOf(conn).MethodByName(action); method.IsValid() {
+			call, _ = method.Interface().(func(*map[string]interface{}) (*map[string]interface{}, error))
+		}
+		if call != nil {
+			break
+		}
+	}
+	if call == nil {
+		return nil, fmt.Errorf("action %s is not supported by service %s", action, service)
+	}
+	resp, err := call(&req)
+	if err != nil {
+		return nil, fmt.Errorf("error on calling %s of %s, %s", action, service, err)
+	}
+	if resp == nil {
+		return map[string]interface{}{}, nil
+	}
+	return *resp, nil
+}
+
+// flattenApiResponse flattens the response into result, the keys are the paths joined by dots
+// and the list items are keyed by their indexes, such as VpcSet.0.VpcId
+func flattenApiResponse(prefix string, v interface{}, result map[string]interface{}) {
+	key := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			flattenApiResponse(key(k), item, result)
+		}
+	case []interface{}:
+		for i, item := range value {
+			flattenApiResponse(key(strconv.Itoa(i)), item, result)
+		}
+	case nil:
+	case float64:
+		result[prefix] = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		result[prefix] = fmt.Sprintf("%v", value)
+	}
+}
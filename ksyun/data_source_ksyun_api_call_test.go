@@ -0,0 +1,132 @@
+package ksyun
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitKsyunApiCallDataSource_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testUnitDataApiCallConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_api_call.foo"),
+					resource.TestCheckResourceAttrPair("data.ksyun_api_call.foo", "response_map.VpcSet.0.VpcId", "ksyun_vpc.default", "id"),
+					resource.TestCheckResourceAttr("data.ksyun_api_call.foo", "response_map.VpcSet.0.VpcName", "tf-unit-vpc-api-call"),
+					resource.TestMatchResourceAttr("data.ksyun_api_call.foo", "response", regexp.MustCompile(`"VpcName":"tf-unit-vpc-api-call"`)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKsyunApiCallDataSource_notReadOnly(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config:      api.config(testUnitDataApiCallCreateConfig),
+				ExpectError: regexp.MustCompile("must be a read only action"),
+			},
+		},
+	})
+	assert.Equal(t, 0, api.called("vpc", "CreateVpc"))
+}
+
+func TestCallReadOnlyAction(t *testing.T) {
+	a := assert.New(t)
+	client := &KsyunClient{}
+
+	_, err := callReadOnlyAction(client, "vpc", "DeleteVpc", nil)
+	a.EqualError(err, "action DeleteVpc of vpc is not read only, only the actions starting with Describe, List, Get are allowed")
+	_, err = callReadOnlyAction(client, "vpc", "DescribeVpcs", nil)
+	a.EqualError(err, "service vpc is not supported")
+}
+
+func TestCallReadOnlyAction_serviceConns(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	c := Config{
+		AccessKey: fakeApiAccessKey,
+		SecretKey: fakeApiSecretKey,
+		Region:    fakeApiRegion,
+		Endpoints: map[string]string{"tag": api.server.URL},
+	}
+	client, err := c.Client()
+	a.Nil(err)
+
+	id := api.uuid()
+	api.tags[id] = map[string]string{"tier": "web"}
+	resp, err := callReadOnlyAction(client, "tag", "ListTagsByResourceIds", map[string]interface{}{
+		"ResourceType":  "vpc",
+		"ResourceUuids": id,
+	})
+	a.Nil(err)
+	a.Equal(1, api.called("tagv2", "ListTagsByResourceIds"))
+	a.Len(resp["Tags"], 1)
+
+	_, err = callReadOnlyAction(client, "kcs", "DescribeVpcs", nil)
+	a.EqualError(err, "action DescribeVpcs is not supported by service kcs")
+
+	for service := range client.serviceHandlers() {
+		a.Contains(client.serviceConns(), service)
+	}
+	a.Len(client.serviceConns(), len(client.serviceHandlers()))
+}
+
+func TestFlattenApiResponse(t *testing.T) {
+	a := assert.New(t)
+	result := make(map[string]interface{})
+	flattenApiResponse("", map[string]interface{}{
+		"RequestId":  "req-1",
+		"TotalCount": float64(1000000),
+		"VpcSet": []interface{}{
+			map[string]interface{}{"VpcId": "vpc-1", "IsDefault": false, "Tags": nil},
+		},
+	}, result)
+	a.Equal(map[string]interface{}{
+		"RequestId":          "req-1",
+		"TotalCount":         "1000000",
+		"VpcSet.0.VpcId":     "vpc-1",
+		"VpcSet.0.IsDefault": "false",
+	}, result)
+}
+
+const testUnitDataApiCallConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-unit-vpc-api-call"
+  cidr_block = "192.168.0.0/16"
+}
+data "ksyun_api_call" "foo" {
+  service = "vpc"
+  action  = "DescribeVpcs"
+  parameters = {
+    "VpcId.1" = ksyun_vpc.default.id
+  }
+}
+`
+
+const testUnitDataApiCallCreateConfig = `
+data "ksyun_api_call" "foo" {
+  service = "vpc"
+  action  = "CreateVpc"
+  parameters = {
+    VpcName   = "tf-unit-vpc-api-call"
+    CidrBlock = "192.168.0.0/16"
+  }
+}
+`
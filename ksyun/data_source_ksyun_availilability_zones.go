@@ -12,6 +12,8 @@ func dataSourceKsyunAvailabilityZones() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
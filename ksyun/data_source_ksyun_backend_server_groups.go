@@ -36,6 +36,8 @@ func dataSourceKsyunBackendServerGroups() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -34,6 +34,8 @@ func dataSourceKsyunBareMetalImages() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
@@ -26,6 +26,8 @@ func dataSourceKsyunBareMetalRaidAttributes() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
@@ -30,10 +30,14 @@ func dataSourceKsyunBareMetals() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringIsValidRegExp,
 			},
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
@@ -41,10 +41,14 @@ func dataSourceKsyunBandWidthShares() *schema.Resource {
 				ValidateFunc: validation.StringIsValidRegExp,
 			},
 
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
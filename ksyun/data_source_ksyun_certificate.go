@@ -0,0 +1,14 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunCertificate() *schema.Resource {
+	return singularDataSource{
+		list:        dataSourceKsyunCertificates,
+		targetField: "certificates",
+		idField:     "certificate_id",
+		name:        "certificate",
+	}.resource()
+}
@@ -0,0 +1,82 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunDataGuardGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunDataGuardGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"data_guard_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"data_guard_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"data_guard_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"data_guard_capacity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"instances": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"instance_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"instance_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunDataGuardGroupsRead(d *schema.ResourceData, meta interface{}) error {
+	kecService := KecService{meta.(*KsyunClient)}
+	return kecService.ReadAndSetDataGuardGroups(d, dataSourceKsyunDataGuardGroups())
+}
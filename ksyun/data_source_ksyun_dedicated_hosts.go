@@ -0,0 +1,121 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunDedicatedHosts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunDedicatedHostsRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"project_id": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"dedicated_hosts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dedicated_host_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dedicated_host_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dedicated_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dedicated_cluster_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"charge_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpu": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"available_cpu": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"available_memory": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"instance_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"create_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunDedicatedHostsRead(d *schema.ResourceData, meta interface{}) error {
+	dedicatedService := DedicatedService{meta.(*KsyunClient)}
+	return dedicatedService.ReadAndSetDedicatedHosts(d, dataSourceKsyunDedicatedHosts())
+}
@@ -30,6 +30,9 @@ func dataSourceKsyunEips() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"tags":          tagsFilterSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
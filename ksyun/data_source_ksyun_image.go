@@ -0,0 +1,16 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunImage() *schema.Resource {
+	return singularDataSource{
+		list:        dataSourceKsyunImages,
+		targetField: "images",
+		idField:     "image_id",
+		name:        "image",
+		// most_recent picks the latest created image when the arguments match more than one
+		mostRecentField: "creation_date",
+	}.resource()
+}
@@ -0,0 +1,14 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunInstance() *schema.Resource {
+	return singularDataSource{
+		list:        dataSourceKsyunInstances,
+		targetField: "instances",
+		idField:     "instance_id",
+		name:        "instance",
+	}.resource()
+}
@@ -0,0 +1,153 @@
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunInstanceTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunInstanceTypesRead,
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"instance_families": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"min_cpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_cpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"min_memory": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_memory": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"min_gpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_gpu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"gpu_model": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sort_by": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceTypesSortBy,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"instance_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_family": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_family_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpu": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"gpu": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"gpu_model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"local_disk_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"network_bandwidth": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"availability_zones": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunInstanceTypesRead(d *schema.ResourceData, meta interface{}) error {
+	kecService := KecService{meta.(*KsyunClient)}
+	return kecService.ReadAndSetKecInstanceTypes(d, dataSourceKsyunInstanceTypes())
+}
+
+// validateInstanceTypesSortBy rejects sorting by price explicitly, the kec and billing apis of the sdk
+// do not return the prices of instance types
+func validateInstanceTypesSortBy(v interface{}, k string) (ws []string, errors []error) {
+	if v.(string) == "price" {
+		errors = append(errors, fmt.Errorf("%q does not support price, the api does not return the prices of instance types, "+
+			"use cpu or memory instead", k))
+		return
+	}
+	return validation.StringInSlice([]string{"cpu", "memory"}, false)(v, k)
+}
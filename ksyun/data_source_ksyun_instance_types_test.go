@@ -0,0 +1,87 @@
+package ksyun
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestUnitKsyunInstanceTypesDataSource_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitDataInstanceTypesConfig, fakeAvailabilityZones[1])),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.cpu", "total_count", "2"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.cpu", "instance_types.0.instance_type", "S6.4B"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.cpu", "instance_types.0.cpu", "4"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.cpu", "instance_types.0.memory", "8"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.cpu", "instance_types.1.instance_type", "N3.4B"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.cpu", "instance_types.1.availability_zones.#", "3"),
+
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.memory", "total_count", "5"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.memory", "instance_types.0.instance_type", "S6.1A"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.memory", "instance_types.4.instance_type", "S6.8C"),
+
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.gpu", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.gpu", "instance_types.0.id", "P3I.8D"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.gpu", "instance_types.0.gpu", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.gpu", "instance_types.0.gpu_model", "V100"),
+
+					resource.TestCheckResourceAttr("data.ksyun_instance_types.family", "total_count", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKsyunInstanceTypesDataSource_sortByPrice(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(`
+data "ksyun_instance_types" "price" {
+  sort_by = "price"
+}
+`),
+				ExpectError: regexp.MustCompile("does not support price"),
+			},
+		},
+	})
+}
+
+const testUnitDataInstanceTypesConfig = `
+data "ksyun_instance_types" "cpu" {
+  availability_zone = %q
+  min_cpu           = 4
+  max_cpu           = 4
+  min_memory        = 8
+  sort_by           = "cpu"
+}
+
+data "ksyun_instance_types" "memory" {
+  max_gpu = 0
+  sort_by = "memory"
+}
+
+data "ksyun_instance_types" "gpu" {
+  min_gpu   = 1
+  gpu_model = "V100"
+}
+
+data "ksyun_instance_types" "family" {
+  instance_families = ["S6"]
+}
+`
@@ -39,6 +39,14 @@ func dataSourceKsyunInstances() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"tags":          tagsFilterSchema(),
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 
 			"total_count": {
 				Type:     schema.TypeInt,
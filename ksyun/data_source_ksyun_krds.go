@@ -20,10 +20,14 @@ func dataSourceKsyunKrds() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -287,6 +291,10 @@ func dataSourceKsyunKrdsRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error on reading Instance(krds) body %q, %+v", d.Id(), (*resp)["Error"])
 	}
 	instances := bodyData["Instances"].([]interface{})
+	instances, err = filterResourcesByTags(d, meta.(*KsyunClient), "krds", "DBInstanceIdentifier", instances)
+	if err != nil {
+		return fmt.Errorf("error on reading Instance(krds) tags, %s", err)
+	}
 
 	krdsIds := make([]string, len(instances))
 	krdsMapList := make([]map[string]interface{}, len(instances))
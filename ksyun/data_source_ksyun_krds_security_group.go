@@ -24,6 +24,8 @@ func dataSourceKsyunKrdsSecurityGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -0,0 +1,14 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunLb() *schema.Resource {
+	return singularDataSource{
+		list:        dataSourceKsyunLbs,
+		targetField: "lbs",
+		idField:     "load_balancer_id",
+		name:        "load balancer",
+	}.resource()
+}
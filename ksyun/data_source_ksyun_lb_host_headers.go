@@ -20,6 +20,8 @@ func dataSourceKsyunListenerHostHeaders() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -28,6 +28,8 @@ func dataSourceKsyunSlbRules() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
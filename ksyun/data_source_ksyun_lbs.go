@@ -51,6 +51,9 @@ func dataSourceKsyunLbs() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"tags":          tagsFilterSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
@@ -21,6 +21,8 @@ func dataSourceKsyunLines() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"line_name": {
 				Type:     schema.TypeString,
 				Optional: true,
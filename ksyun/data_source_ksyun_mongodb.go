@@ -14,10 +14,14 @@ func dataSourceKsyunMongodbs() *schema.Resource {
 		Read: dataSourceMongodbInstancesRead,
 		// Define input and output parameters
 		Schema: map[string]*schema.Schema{
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"instance_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -232,6 +236,10 @@ func dataSourceMongodbInstancesRead(d *schema.ResourceData, meta interface{}) er
 		nextToken = strconv.Itoa(int((*resp)["limit"].(float64)) + int((*resp)["offset"].(float64)))
 	}
 
+	allInstances, err := filterResourcesByTags(d, meta.(*KsyunClient), "mongodb", "InstanceId", allInstances)
+	if err != nil {
+		return fmt.Errorf("error on reading instance tags, %s", err)
+	}
 	values := GetSubSliceDByRep(allInstances, mongodbInstanceKeys)
 	for _, v := range values {
 		v["ip"] = v["i_p"]
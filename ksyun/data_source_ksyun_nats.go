@@ -24,10 +24,14 @@ func dataSourceKsyunNats() *schema.Resource {
 				ValidateFunc: validation.StringIsValidRegExp,
 			},
 
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
@@ -14,10 +14,14 @@ func dataSourceKsyunRabbitmqs() *schema.Resource {
 		Read: dataSourceRabbitmqInstancesRead,
 		// Define input and output parameters
 		Schema: map[string]*schema.Schema{
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -68,8 +72,9 @@ func dataSourceKsyunRabbitmqs() *schema.Resource {
 							Computed: true,
 						},
 						"instance_password": {
-							Type:     schema.TypeString,
-							Computed: true,
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
 						},
 						"vpc_id": {
 							Type:     schema.TypeString,
@@ -257,6 +262,10 @@ func dataSourceRabbitmqInstancesRead(d *schema.ResourceData, meta interface{}) e
 		nextToken = strconv.Itoa(int(item["limit"].(float64)) + int(item["Offset"].(float64)))
 	}
 
+	allInstances, err = filterResourcesByTags(d, meta.(*KsyunClient), "rabbitmq", "InstanceId", allInstances)
+	if err != nil {
+		return fmt.Errorf("error on reading instance tags, %s", err)
+	}
 	values := GetSubSliceDByRep(allInstances, rabbitmqInstanceKeys)
 
 	if err := dataSourceKscSave(d, "instances", []string{}, values); err != nil {
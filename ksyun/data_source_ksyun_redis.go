@@ -15,10 +15,14 @@ func dataSourceRedisInstances() *schema.Resource {
 		Read: dataSourceRedisInstancesRead,
 		// Define input and output parameters
 		Schema: map[string]*schema.Schema{
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -291,6 +295,11 @@ func dataSourceRedisInstancesRead(d *schema.ResourceData, meta interface{}) erro
 		nextToken = strconv.Itoa(int(item["limit"].(float64)) + int(item["offset"].(float64)))
 	}
 
+	allInstances, err = filterResourcesByTags(d, meta.(*KsyunClient), "kcs", "cacheId", allInstances)
+	if err != nil {
+		return fmt.Errorf("error on reading instance tags, %s", err)
+	}
+
 	readOnlyAction := "DescribeCacheReadonlyNode"
 	readOnlyConn := meta.(*KsyunClient).kcsv2conn
 	readOnlyReq := make(map[string]interface{})
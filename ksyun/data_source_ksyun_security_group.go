@@ -0,0 +1,14 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunSecurityGroup() *schema.Resource {
+	return singularDataSource{
+		list:        dataSourceKsyunSecurityGroups,
+		targetField: "security_groups",
+		idField:     "id",
+		name:        "security group",
+	}.resource()
+}
@@ -2,6 +2,7 @@ package ksyun
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func dataSourceKsyunSecurityGroups() *schema.Resource {
@@ -17,10 +18,19 @@ func dataSourceKsyunSecurityGroups() *schema.Resource {
 				Set: schema.HashString,
 			},
 
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 
 			"total_count": {
 				Type:     schema.TypeInt,
@@ -0,0 +1,110 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func dataSourceKsyunSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKsyunSnapshotsRead,
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.ValidateRegexp,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"volume_category": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"snapshot_status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"total_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"snapshots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"snapshot_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"volume_category": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"snapshot_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"snapshot_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"progress": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"create_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKsyunSnapshotsRead(d *schema.ResourceData, meta interface{}) error {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	return ebsService.ReadAndSetSnapshots(d, dataSourceKsyunSnapshots())
+}
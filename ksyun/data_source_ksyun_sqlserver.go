@@ -21,10 +21,14 @@ func dataSourceKsyunSqlServer() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 			"total_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -415,6 +419,10 @@ func dataSourceKsyunSqlServerRead(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("error on reading Instance(sqlserver) body %+v", (*resp)["Error"])
 	}
 	instances := bodyData["Instances"].([]interface{})
+	instances, err = filterResourcesByTags(d, meta.(*KsyunClient), "sqlserver", "DBInstanceIdentifier", instances)
+	if err != nil {
+		return fmt.Errorf("error on reading Instance(sqlserver) tags, %s", err)
+	}
 	if len(instances) == 0 {
 		return fmt.Errorf("empty on reading Instance(sqlserver) body %+v", *resp)
 	}
@@ -0,0 +1,14 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunSubnet() *schema.Resource {
+	return singularDataSource{
+		list:        dataSourceKsyunSubnets,
+		targetField: "subnets",
+		idField:     "id",
+		name:        "subnet",
+	}.resource()
+}
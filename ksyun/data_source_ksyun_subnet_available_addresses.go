@@ -22,6 +22,7 @@ func dataSourceKsyunSubnetAvailableAddresses() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
 
 			"total_count": {
 				Type:     schema.TypeInt,
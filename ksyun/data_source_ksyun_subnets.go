@@ -69,10 +69,19 @@ func dataSourceKsyunSubnets() *schema.Resource {
 				Set: schema.HashString,
 			},
 
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 
 			"total_count": {
 				Type:     schema.TypeInt,
@@ -49,3 +49,59 @@ data "ksyun_subnets" "foo" {
 	output_file = "output_result"
 }
 `
+
+func TestUnitKsyunSubnetsDataSource_filter(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testUnitDataSubnetsFilterConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_subnets.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_subnets.foo", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_subnets.foo", "subnets.0.name", "db-2"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitDataSubnetsFilterConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "tf-unit-vpc"
+  cidr_block = "10.7.0.0/16"
+}
+resource "ksyun_subnet" "default" {
+  count             = 3
+  subnet_name       = element(["db-1", "web-1", "db-2"], count.index)
+  cidr_block        = "10.7.${count.index}.0/24"
+  subnet_type       = "Normal"
+  dhcp_ip_from      = "10.7.${count.index}.2"
+  dhcp_ip_to        = "10.7.${count.index}.253"
+  gateway_ip        = "10.7.${count.index}.1"
+  vpc_id            = ksyun_vpc.default.id
+  availability_zone = "cn-beijing-6a"
+  tags = {
+    tier = element(["db", "web", "db"], count.index)
+  }
+}
+data "ksyun_subnets" "foo" {
+  ids     = ksyun_subnet.default.*.id
+  vpc_ids = [ksyun_vpc.default.id]
+  tags = {
+    tier = "db"
+  }
+  filter {
+    name   = "name"
+    values = ["*-2"]
+  }
+  filter {
+    name   = "subnet_type"
+    values = ["Normal", "Reserve"]
+  }
+}
+`
@@ -14,6 +14,8 @@ func dataSourceKsyunTags() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
 
 			"keys": {
 				Type:     schema.TypeSet,
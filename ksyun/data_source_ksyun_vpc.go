@@ -0,0 +1,14 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func dataSourceKsyunVpc() *schema.Resource {
+	return singularDataSource{
+		list:        dataSourceKsyunVpcs,
+		targetField: "vpcs",
+		idField:     "id",
+		name:        "vpc",
+	}.resource()
+}
@@ -0,0 +1,76 @@
+package ksyun
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestUnitKsyunVPCDataSource_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testUnitDataVPCConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ksyun_vpc.foo", "id", "ksyun_vpc.default.0", "id"),
+					resource.TestCheckResourceAttrPair("data.ksyun_vpc.foo", "name", "ksyun_vpc.default.0", "vpc_name"),
+					resource.TestCheckResourceAttr("data.ksyun_vpc.foo", "cidr_block", "192.168.0.0/16"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitKsyunVPCDataSource_notExactlyOne(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config:      api.config(testUnitDataVPCMultipleConfig),
+				ExpectError: regexp.MustCompile("2 vpcs matched the query"),
+			},
+			{
+				Config:      api.config(testUnitDataVPCNoneConfig),
+				ExpectError: regexp.MustCompile("no vpc matched the query"),
+			},
+		},
+	})
+}
+
+const testUnitDataVPCConfig = `
+resource "ksyun_vpc" "default" {
+  count      = 2
+  vpc_name   = "tf-unit-vpc-${count.index}"
+  cidr_block = "192.168.0.0/16"
+}
+data "ksyun_vpc" "foo" {
+  ids = [ksyun_vpc.default[0].id]
+}
+`
+
+const testUnitDataVPCMultipleConfig = `
+resource "ksyun_vpc" "default" {
+  count      = 2
+  vpc_name   = "tf-unit-vpc-${count.index}"
+  cidr_block = "192.168.0.0/16"
+}
+data "ksyun_vpc" "foo" {
+  ids = ksyun_vpc.default.*.id
+}
+`
+
+const testUnitDataVPCNoneConfig = `
+data "ksyun_vpc" "foo" {
+  ids = ["vpc-not-exist"]
+}
+`
@@ -25,10 +25,19 @@ func dataSourceKsyunVpcs() *schema.Resource {
 				ValidateFunc: validation.StringIsValidRegExp,
 			},
 
+			"tags": tagsFilterSchema(),
+
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"output_format": dataSourceOutputFormatSchema(),
+			"filter":        dataSourceFiltersSchema(),
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 
 			"total_count": {
 				Type:     schema.TypeInt,
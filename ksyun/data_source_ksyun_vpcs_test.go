@@ -32,3 +32,101 @@ data "ksyun_vpcs" "foo" {
 	output_file = "output_result"
 }
 `
+
+func TestUnitKsyunVPCsDataSource_maxResults(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testUnitDataVPCsMaxResultsConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDExists("data.ksyun_vpcs.foo"),
+					resource.TestCheckResourceAttr("data.ksyun_vpcs.foo", "total_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitDataVPCsMaxResultsConfig = `
+resource "ksyun_vpc" "default" {
+  count      = 3
+  vpc_name   = "tf-unit-vpc-${count.index}"
+  cidr_block = "192.168.0.0/16"
+}
+data "ksyun_vpcs" "foo" {
+  ids         = ksyun_vpc.default.*.id
+  max_results = 2
+}
+`
+
+func TestUnitKsyunVPCsDataSource_maxResultsNameRegex(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testUnitDataVPCsMaxResultsNameRegexConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ksyun_vpcs.foo", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_vpcs.foo", "vpcs.0.name", "tf-unit-vpc-2"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitDataVPCsMaxResultsNameRegexConfig = `
+resource "ksyun_vpc" "default" {
+  count      = 3
+  vpc_name   = "tf-unit-vpc-${count.index}"
+  cidr_block = "192.168.0.0/16"
+}
+data "ksyun_vpcs" "foo" {
+  ids         = ksyun_vpc.default.*.id
+  name_regex  = "-2$"
+  max_results = 1
+}
+`
+
+func TestUnitKsyunVPCsDataSource_maxResultsFilter(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: api.providers(),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testUnitDataVPCsMaxResultsFilterConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ksyun_vpcs.foo", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_vpcs.foo", "vpcs.0.name", "tf-unit-vpc-2"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitDataVPCsMaxResultsFilterConfig = `
+resource "ksyun_vpc" "default" {
+  count      = 3
+  vpc_name   = "tf-unit-vpc-${count.index}"
+  cidr_block = "192.168.0.0/16"
+}
+data "ksyun_vpcs" "foo" {
+  ids         = ksyun_vpc.default.*.id
+  max_results = 1
+  filter {
+    name   = "name"
+    values = ["*-2"]
+  }
+}
+`
@@ -1,5 +1,11 @@
 package ksyun
 
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"strings"
+)
+
 type endpoint string
 
 const (
@@ -21,3 +27,100 @@ func GetInsecureEndpointURL(region string) string {
 func GetEndpointURL(region string) string {
 	return publicSecureEndpoint.GetURL()
 }
+
+// endpointServices is the services which can be overridden by the endpoints block of provider
+var endpointServices = []string{
+	"vpc",
+	"eip",
+	"slb",
+	"kec",
+	"sqlserver",
+	"krds",
+	"kcm",
+	"sks",
+	"kcs",
+	"epc",
+	"ebs",
+	"mongodb",
+	"iam",
+	"rabbitmq",
+	"bws",
+	"tag",
+	"ks3",
+	"sts",
+}
+
+func endpointsSchema() *schema.Resource {
+	endpoints := make(map[string]*schema.Schema)
+	for _, service := range endpointServices {
+		endpoints[service] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: descriptions["endpoint"],
+		}
+	}
+	return &schema.Resource{
+		Schema: endpoints,
+	}
+}
+
+func apiRateLimitsSchema() *schema.Resource {
+	limits := make(map[string]*schema.Schema)
+	for _, service := range endpointServices {
+		if service == "sts" {
+			continue
+		}
+		limits[service] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  descriptions["api_rate_limit"],
+		}
+	}
+	return &schema.Resource{
+		Schema: limits,
+	}
+}
+
+func expandProviderApiRateLimits(v interface{}) map[string]int {
+	limits := make(map[string]int)
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return limits
+	}
+	for service, qps := range list[0].(map[string]interface{}) {
+		if qps.(int) > 0 {
+			limits[service] = qps.(int)
+		}
+	}
+	return limits
+}
+
+func expandProviderEndpoints(v interface{}) map[string]string {
+	endpoints := make(map[string]string)
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return endpoints
+	}
+	m := list[0].(map[string]interface{})
+	for _, service := range endpointServices {
+		if endpoint, ok := m[service]; ok && endpoint.(string) != "" {
+			endpoints[service] = endpoint.(string)
+		}
+	}
+	return endpoints
+}
+
+// parseEndpoint split the endpoint to domain and ssl flag, endpoint without scheme will use the defaultSSL
+func parseEndpoint(endpoint string, defaultSSL bool) (string, bool) {
+	endpoint = strings.TrimSuffix(strings.TrimSpace(endpoint), "/")
+	if strings.HasPrefix(endpoint, "https://") {
+		return strings.TrimPrefix(endpoint, "https://"), true
+	}
+	if strings.HasPrefix(endpoint, "http://") {
+		return strings.TrimPrefix(endpoint, "http://"), false
+	}
+	return endpoint, defaultSSL
+}
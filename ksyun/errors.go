@@ -2,14 +2,101 @@ package ksyun
 
 import (
 	"fmt"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
 )
 
+// error categories used to classify api and provider errors
 const (
-	NotFound = "Notfound"
+	NotFound      = "Notfound"
+	Conflict      = "Conflict"
+	Throttled     = "Throttled"
+	QuotaExceeded = "QuotaExceeded"
+	Unauthorized  = "Unauthorized"
 )
 
+// serviceErrorCodes maps the error codes returned by each openapi service to an error category.
+// codes which are not listed here fall back to the generic rules in errorCodeCategory
+var serviceErrorCodes = map[string]map[string]string{
+	"kec": {
+		"InvalidInstanceId.NotFound":  NotFound,
+		"InstanceNotFound":            NotFound,
+		"InvalidImageId.NotFound":     NotFound,
+		"InvalidKeyPair.NotFound":     NotFound,
+		"InvalidDataGuardId.NotFound": NotFound,
+		"IncorrectInstanceStatus":     Conflict,
+		"InvalidInstanceState":        Conflict,
+		"InstanceLimitExceeded":       QuotaExceeded,
+		"InstanceQuotaExceeded":       QuotaExceeded,
+	},
+	"vpc": {
+		"VpcNotFound":              NotFound,
+		"SubnetNotFound":           NotFound,
+		"SecurityGroupNotFound":    NotFound,
+		"NetworkInterfaceNotFound": NotFound,
+		"NatNotFound":              NotFound,
+		"RouteNotFound":            NotFound,
+		"NetworkAclNotFound":       NotFound,
+		"InvalidVpcId.NotFound":    NotFound,
+		"InvalidSubnetId.NotFound": NotFound,
+		"DependencyViolation":      Conflict,
+		"VpcLimitExceeded":         QuotaExceeded,
+		"SubnetLimitExceeded":      QuotaExceeded,
+	},
+	"eip": {
+		"AddressNotFound":              NotFound,
+		"InvalidAllocationId.NotFound": NotFound,
+		"AddressLimitExceeded":         QuotaExceeded,
+	},
+	"slb": {
+		"LoadBalancerNotFound":      NotFound,
+		"ListenerNotFound":          NotFound,
+		"HealthCheckNotFound":       NotFound,
+		"RealServerNotFound":        NotFound,
+		"LoadBalancerLimitExceeded": QuotaExceeded,
+	},
+	"ebs": {
+		"VolumeNotFound":           NotFound,
+		"InvalidVolumeId.NotFound": NotFound,
+		"SnapshotNotFound":         NotFound,
+		"InvalidVolumeStatus":      Conflict,
+		"VolumeLimitExceeded":      QuotaExceeded,
+	},
+	"krds": {
+		"DBInstanceNotFound":      NotFound,
+		"DBInstanceNotExist":      NotFound,
+		"InvalidDBInstanceState":  Conflict,
+		"DBInstanceQuotaExceeded": QuotaExceeded,
+	},
+	"kcs": {
+		// the cache clusters which are deleted are described with InvalidAction
+		"InvalidAction": NotFound,
+	},
+	"mongodb": {
+		"InstanceNotFound": NotFound,
+	},
+	"rabbitmq": {
+		"InstanceNotFound": NotFound,
+	},
+	"iam": {
+		"UserNoSuchEntity":    NotFound,
+		"GroupNoSuchEntity":   NotFound,
+		"NoSuchEntity":        NotFound,
+		"EntityAlreadyExists": Conflict,
+	},
+	"common": {
+		"Unauthorized":          Unauthorized,
+		"AccessDenied":          Unauthorized,
+		"Forbidden":             Unauthorized,
+		"SignatureDoesNotMatch": Unauthorized,
+		"InvalidAccessKeyId":    Unauthorized,
+		"RequestLimitExceeded":  Throttled,
+		"Throttling":            Throttled,
+		"TooManyRequests":       Throttled,
+	},
+}
+
 type ProviderError struct {
 	errorCode string
 	message   string
@@ -27,55 +114,105 @@ func (err *ProviderError) Message() string {
 	return err.message
 }
 
-/*
-	func newNotFoundError(str string) error {
-		return &ProviderError{
-			errorCode: NotFound,
-			message:   str,
-		}
+func newNotFoundError(str string) error {
+	return &ProviderError{
+		errorCode: NotFound,
+		message:   str,
 	}
+}
 
-	func getNotFoundMessage(product, id string) string {
-		return fmt.Sprintf("the specified %s %s is not found", product, id)
-	}
+// serviceRequestFailure is the request failure of an openapi service, its code is classified by the codes of the service
+type serviceRequestFailure struct {
+	awserr.RequestFailure
+	service string
+}
 
-	func isNotFoundError(err error) bool {
-		if e, ok := err.(*ProviderError); ok &&
-			(e.ErrorCode() == NotFound || strings.Contains(strings.ToLower(e.Message()), NotFound)) {
-			return true
+// newServiceRequestFailure binds the request failure err to service, other errors are returned as they are
+func newServiceRequestFailure(service string, err error) error {
+	if failure, ok := err.(awserr.RequestFailure); ok {
+		if _, ok := err.(*serviceRequestFailure); !ok {
+			return &serviceRequestFailure{RequestFailure: failure, service: service}
 		}
+	}
+	return err
+}
 
-		return false
+// errorCategory returns the category of err, or an empty string when err can not be classified
+func errorCategory(err error) string {
+	if err == nil {
+		return ""
 	}
-*/
-func notFoundError(err error) bool {
-	if ksyunError, ok := err.(awserr.RequestFailure); ok && ksyunError.StatusCode() == 404 {
-		return true
+	service := ""
+	if failure, ok := err.(*serviceRequestFailure); ok {
+		service = failure.service
 	}
-	errMessage := strings.ToLower(err.Error())
-	if strings.Contains(errMessage, "notfound") ||
-		strings.Contains(errMessage, "not found") ||
-		strings.Contains(errMessage, "not exist") ||
-		strings.Contains(errMessage, "not associate") ||
-		strings.Contains(errMessage, "invalid") ||
-		strings.Contains(errMessage, "not_found") {
-		//strings.Contains(errMessage,"notfound"){
-		return true
+	switch e := err.(type) {
+	case *ProviderError:
+		return e.ErrorCode()
+	case awserr.RequestFailure:
+		if category := errorCodeCategory(service, e.Code()); category != "" {
+			return category
+		}
+		switch {
+		case e.StatusCode() == 404:
+			return NotFound
+		case e.StatusCode() == 409:
+			return Conflict
+		case e.StatusCode() == 429:
+			return Throttled
+		case e.StatusCode() == 401 || e.StatusCode() == 403:
+			return Unauthorized
+		}
+	case awserr.Error:
+		return errorCodeCategory(service, e.Code())
 	}
-	return false
+	return ""
 }
 
-func notFoundErrorNew(err error) bool {
-	if ksyunError, ok := err.(awserr.RequestFailure); ok && ksyunError.StatusCode() == 404 {
-		return true
+// errorCodeCategory classifies code by the codes of service, then by the common codes and the generic rules
+func errorCodeCategory(service, code string) string {
+	if code == "" {
+		return ""
 	}
-	errMessage := strings.ToLower(err.Error())
-	if strings.Contains(errMessage, "notfound") ||
-		strings.Contains(errMessage, "not_found") {
-		//strings.Contains(errMessage,"notfound"){
-		return true
+	if category, ok := serviceErrorCodes[service][code]; ok {
+		return category
 	}
-	return false
+	if category, ok := serviceErrorCodes["common"][code]; ok {
+		return category
+	}
+	lower := strings.ToLower(code)
+	switch {
+	case strings.HasSuffix(lower, "notfound") ||
+		strings.HasSuffix(lower, "not_found") ||
+		strings.HasSuffix(lower, "notexist") ||
+		strings.HasSuffix(lower, "nosuchentity"):
+		return NotFound
+	case strings.Contains(lower, "throttl") ||
+		strings.Contains(lower, "requestlimitexceeded") ||
+		strings.Contains(lower, "toomanyrequests"):
+		return Throttled
+	case strings.Contains(lower, "quota") ||
+		strings.HasSuffix(lower, "limitexceeded"):
+		return QuotaExceeded
+	case strings.Contains(lower, "inuse") ||
+		strings.Contains(lower, "in_use") ||
+		strings.Contains(lower, "conflict") ||
+		strings.Contains(lower, "alreadyexist"):
+		return Conflict
+	case strings.Contains(lower, "unauthorized") ||
+		strings.Contains(lower, "accessdenied") ||
+		strings.Contains(lower, "forbidden"):
+		return Unauthorized
+	}
+	return ""
+}
+
+func isNotFoundError(err error) bool {
+	return errorCategory(err) == NotFound
+}
+
+func isThrottledError(err error) bool {
+	return errorCategory(err) == Throttled
 }
 
 func inUseError(err error) bool {
@@ -91,3 +228,67 @@ func inUseError(err error) bool {
 	}
 	return false
 }
+
+// readOnlyAction reports whether action only reads, so it can be sent again when its response is lost
+func readOnlyAction(action string) bool {
+	for _, prefix := range []string{"Describe", "Get", "List", "Query"} {
+		if strings.HasPrefix(action, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryableActionError reports whether action failed with err can be retried, the actions changing resources
+// are retried on throttling and on resources in use, which are rejected before executing, but not on
+// server side and transport errors, which may be returned after the change is done
+func retryableActionError(action string, err error) bool {
+	if readOnlyAction(action) {
+		return retryableError(err)
+	}
+	return isThrottledError(err) || inUseConflictError(err)
+}
+
+// inUseConflictError reports whether err is a conflict with the state of a resource, such as a resource in use
+// by other operations or in a changing status, which is gone after a while. a resource which already exists
+// conflicts forever, so it is not one of them
+func inUseConflictError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errorCategory(err) == Conflict {
+		if failure, ok := err.(awserr.Error); ok {
+			return !strings.Contains(strings.ToLower(failure.Code()), "alreadyexist")
+		}
+		return true
+	}
+	if failure, ok := err.(awserr.RequestFailure); ok {
+		message := strings.ToLower(failure.Message())
+		return strings.Contains(message, "in use") ||
+			strings.Contains(message, "inuse")
+	}
+	return false
+}
+
+// retryableError reports whether the api call can be retried, such as throttling,
+// server side errors and resources in use by other operations
+func retryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isThrottledError(err) || inUseConflictError(err) {
+		return true
+	}
+	if ksyunError, ok := err.(awserr.RequestFailure); ok {
+		if ksyunError.StatusCode() >= 500 {
+			return true
+		}
+		code := strings.ToLower(ksyunError.Code())
+		return strings.Contains(code, "internalerror") ||
+			strings.Contains(code, "serviceunavailable")
+	}
+	if ksyunError, ok := err.(awserr.Error); ok && ksyunError.Code() == "RequestError" {
+		return true
+	}
+	return false
+}
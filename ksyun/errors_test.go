@@ -0,0 +1,64 @@
+package ksyun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCategory(t *testing.T) {
+	a := assert.New(t)
+	requestFailure := func(code string, status int) error {
+		return awserr.NewRequestFailure(awserr.New(code, "", nil), status, "test")
+	}
+	a.Equal(NotFound, errorCategory(requestFailure("InvalidInstanceId.NotFound", 400)))
+	a.Equal(NotFound, errorCategory(requestFailure("VpcNotFound", 400)))
+	a.Equal(NotFound, errorCategory(requestFailure("UserNoSuchEntity", 400)))
+	a.Equal(NotFound, errorCategory(requestFailure("Unknown", 404)))
+	a.Equal(NotFound, errorCategory(newNotFoundError("Vpc vpc-1 not exist")))
+	a.Equal(Conflict, errorCategory(newServiceRequestFailure("vpc", requestFailure("DependencyViolation", 400))))
+	// the codes of a service are not used to classify the errors of the other services
+	a.Equal("", errorCategory(requestFailure("DependencyViolation", 400)))
+	a.Equal("", errorCategory(newServiceRequestFailure("kec", requestFailure("DependencyViolation", 400))))
+	a.Equal(Conflict, errorCategory(newServiceRequestFailure("kec", requestFailure("IncorrectInstanceStatus", 400))))
+	a.Equal(NotFound, errorCategory(newServiceRequestFailure("kcs", requestFailure("InvalidAction", 400))))
+	a.Equal(NotFound, errorCategory(newServiceRequestFailure("mongodb", requestFailure("InstanceNotFound", 400))))
+	a.Equal("", errorCategory(newServiceRequestFailure("kec", requestFailure("InvalidAction", 400))))
+	a.Equal(Conflict, errorCategory(requestFailure("Unknown", 409)))
+	a.Equal(Throttled, errorCategory(requestFailure("RequestLimitExceeded", 400)))
+	a.Equal(Throttled, errorCategory(requestFailure("Unknown", 429)))
+	a.Equal(QuotaExceeded, errorCategory(requestFailure("VpcLimitExceeded", 400)))
+	a.Equal(QuotaExceeded, errorCategory(requestFailure("EipQuotaExceeded", 400)))
+	a.Equal(Unauthorized, errorCategory(requestFailure("SignatureDoesNotMatch", 400)))
+	a.Equal(Unauthorized, errorCategory(requestFailure("Unknown", 403)))
+
+	// validation errors must not be treated as not found
+	a.False(isNotFoundError(requestFailure("InvalidParameterValue", 400)))
+	a.False(isNotFoundError(fmt.Errorf("invalid cidr block")))
+	a.False(isNotFoundError(nil))
+}
+
+func TestServiceRequestFailure(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	api.handle("vpc", "DeleteVpc", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return nil, newFakeApiError(400, "DependencyViolation", "the vpc has subnets")
+	})
+	c := Config{
+		AccessKey: fakeApiAccessKey,
+		SecretKey: fakeApiSecretKey,
+		Region:    fakeApiRegion,
+		Endpoints: map[string]string{"vpc": api.server.URL},
+	}
+	client, err := c.Client()
+	a.Nil(err)
+	_, err = client.vpcconn.DeleteVpc(&map[string]interface{}{"VpcId": "vpc"})
+	a.Equal(Conflict, errorCategory(err))
+	failure, ok := err.(awserr.RequestFailure)
+	a.True(ok)
+	a.Equal("DependencyViolation", failure.Code())
+	a.Equal(400, failure.StatusCode())
+}
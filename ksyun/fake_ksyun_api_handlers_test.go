@@ -0,0 +1,1240 @@
+package ksyun
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var fakeAvailabilityZones = []string{fakeApiRegion + "a", fakeApiRegion + "b", fakeApiRegion + "c"}
+
+func (api *fakeKsyunApi) registerVpcHandlers() {
+	api.handle("vpc", "DescribeAvailabilityZones", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		var zones []interface{}
+		for _, zone := range fakeAvailabilityZones {
+			zones = append(zones, map[string]interface{}{"AvailabilityZoneName": zone})
+		}
+		return map[string]interface{}{"AvailabilityZoneInfo": zones}, nil
+	})
+	api.handle("vpc", "CreateVpc", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		cidr, err := req.require("CidrBlock")
+		if err != nil {
+			return nil, err
+		}
+		vpc := api.create("vpc", "VpcId", map[string]interface{}{
+			"VpcName":    req.get("VpcName"),
+			"CidrBlock":  cidr,
+			"IsDefault":  req.getBool("IsDefault"),
+			"CreateTime": api.now(),
+		})
+		return map[string]interface{}{"Vpc": copyFakeItem(vpc)}, nil
+	})
+	api.handle("vpc", "DescribeVpcs", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{"VpcSet": api.list("vpc", req, "VpcId", nil)}, nil
+	})
+	api.handle("vpc", "ModifyVpc", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		vpc, err := api.mustGet("vpc", req.get("VpcId"), "InvalidVpcId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(vpc, "VpcName")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("vpc", "DeleteVpc", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		vpcId := req.get("VpcId")
+		if _, err := api.mustGet("vpc", vpcId, "InvalidVpcId.NotFound"); err != nil {
+			return nil, err
+		}
+		if err := api.checkDependency(vpcId, "VpcId", "subnet", "security_group"); err != nil {
+			return nil, err
+		}
+		api.remove("vpc", vpcId)
+		return map[string]interface{}{"Return": true}, nil
+	})
+
+	api.handle("vpc", "CreateSubnet", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		vpcId := req.get("VpcId")
+		if _, err := api.mustGet("vpc", vpcId, "InvalidVpcId.NotFound"); err != nil {
+			return nil, err
+		}
+		cidr, err := req.require("CidrBlock")
+		if err != nil {
+			return nil, err
+		}
+		subnet := api.create("subnet", "SubnetId", map[string]interface{}{
+			"VpcId":                vpcId,
+			"SubnetName":           req.get("SubnetName"),
+			"CidrBlock":            cidr,
+			"SubnetType":           req.getDefault("SubnetType", "Normal"),
+			"GatewayIp":            req.get("GatewayIp"),
+			"DhcpIpFrom":           req.get("DhcpIpFrom"),
+			"DhcpIpTo":             req.get("DhcpIpTo"),
+			"Dns1":                 req.get("Dns1"),
+			"Dns2":                 req.get("Dns2"),
+			"AvailabilityZone":     req.get("AvailabilityZone"),
+			"AvailabilityZoneName": req.get("AvailabilityZone"),
+			"AvailableIpNumber":    strconv.Itoa(fakeAvailableIpNumber(cidr)),
+			"NetworkAclId":         "",
+			"NatId":                "",
+			"CreateTime":           api.now(),
+		})
+		return map[string]interface{}{"Subnet": copyFakeItem(subnet)}, nil
+	})
+	api.handle("vpc", "DescribeSubnets", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{"SubnetSet": api.list("subnet", req, "SubnetId", map[string]string{
+			"vpc-id":                 "VpcId",
+			"subnet-type":            "SubnetType",
+			"nat-id":                 "NatId",
+			"network-acl-id":         "NetworkAclId",
+			"availability-zone-name": "AvailabilityZoneName",
+		})}, nil
+	})
+	api.handle("vpc", "ModifySubnet", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		subnet, err := api.mustGet("subnet", req.get("SubnetId"), "InvalidSubnetId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(subnet, "SubnetName", "Dns1", "Dns2")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("vpc", "DeleteSubnet", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		subnetId := req.get("SubnetId")
+		if _, err := api.mustGet("subnet", subnetId, "InvalidSubnetId.NotFound"); err != nil {
+			return nil, err
+		}
+		if err := api.checkDependency(subnetId, "SubnetId", "network_interface", "slb"); err != nil {
+			return nil, err
+		}
+		api.remove("subnet", subnetId)
+		return map[string]interface{}{"Return": true}, nil
+	})
+
+	api.handle("vpc", "CreateSecurityGroup", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		vpcId := req.get("VpcId")
+		if _, err := api.mustGet("vpc", vpcId, "InvalidVpcId.NotFound"); err != nil {
+			return nil, err
+		}
+		sg := api.create("security_group", "SecurityGroupId", map[string]interface{}{
+			"VpcId":                 vpcId,
+			"SecurityGroupName":     req.get("SecurityGroupName"),
+			"SecurityGroupType":     "other",
+			"SecurityGroupEntrySet": []interface{}{},
+			"CreateTime":            api.now(),
+		})
+		return map[string]interface{}{"SecurityGroup": copyFakeItem(sg)}, nil
+	})
+	api.handle("vpc", "DescribeSecurityGroups", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{"SecurityGroupSet": api.list("security_group", req, "SecurityGroupId", map[string]string{
+			"vpc-id": "VpcId",
+		})}, nil
+	})
+	api.handle("vpc", "ModifySecurityGroup", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		sg, err := api.mustGet("security_group", req.get("SecurityGroupId"), "SecurityGroupNotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(sg, "SecurityGroupName")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("vpc", "DeleteSecurityGroup", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		sgId := req.get("SecurityGroupId")
+		if _, err := api.mustGet("security_group", sgId, "SecurityGroupNotFound"); err != nil {
+			return nil, err
+		}
+		for _, id := range api.order["network_interface"] {
+			for _, sg := range api.resources["network_interface"][id]["SecurityGroupSet"].([]interface{}) {
+				if sg.(map[string]interface{})["SecurityGroupId"] == sgId {
+					return nil, newFakeApiError(400, "DependencyViolation", "security group %s is in use by %s", sgId, id)
+				}
+			}
+		}
+		api.remove("security_group", sgId)
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("vpc", "AuthorizeSecurityGroupEntry", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		sg, err := api.mustGet("security_group", req.get("SecurityGroupId"), "SecurityGroupNotFound")
+		if err != nil {
+			return nil, err
+		}
+		entry := map[string]interface{}{
+			"SecurityGroupEntryId": api.uuid(),
+			"Direction":            req.get("Direction"),
+			"CidrBlock":            req.get("CidrBlock"),
+			"Protocol":             req.get("Protocol"),
+			"Description":          req.get("Description"),
+		}
+		for _, field := range []string{"PortRangeFrom", "PortRangeTo", "IcmpType", "IcmpCode"} {
+			if v := req.get(field); v != "" {
+				entry[field] = req.getInt(field, 0)
+			}
+		}
+		sg["SecurityGroupEntrySet"] = append(sg["SecurityGroupEntrySet"].([]interface{}), entry)
+		return map[string]interface{}{"SecurityGroupEntryId": []interface{}{entry["SecurityGroupEntryId"]}}, nil
+	})
+	api.handle("vpc", "ModifySecurityGroupEntry", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		_, entry, err := api.securityGroupEntry(req)
+		if err != nil {
+			return nil, err
+		}
+		req.update(entry, "Description")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("vpc", "RevokeSecurityGroupEntry", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		sg, _, err := api.securityGroupEntry(req)
+		if err != nil {
+			return nil, err
+		}
+		var entries []interface{}
+		for _, entry := range sg["SecurityGroupEntrySet"].([]interface{}) {
+			if entry.(map[string]interface{})["SecurityGroupEntryId"] != req.get("SecurityGroupEntryId") {
+				entries = append(entries, entry)
+			}
+		}
+		if entries == nil {
+			entries = []interface{}{}
+		}
+		sg["SecurityGroupEntrySet"] = entries
+		return map[string]interface{}{"Return": true}, nil
+	})
+
+	api.handle("vpc", "DescribeNetworkInterfaces", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{"NetworkInterfaceSet": api.list("network_interface", req, "NetworkInterfaceId", map[string]string{
+			"vpc-id":      "VpcId",
+			"subnet-id":   "SubnetId",
+			"instance-id": "InstanceId",
+		})}, nil
+	})
+}
+
+func (api *fakeKsyunApi) registerEipHandlers() {
+	api.handle("eip", "GetLines", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{"LineSet": []interface{}{fakeEipLine}}, nil
+	})
+	api.handle("eip", "AllocateAddress", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		bandWidth, err := req.require("BandWidth")
+		if err != nil {
+			return nil, err
+		}
+		eip := api.create("eip", "AllocationId", map[string]interface{}{
+			"PublicIp":           fmt.Sprintf("120.92.%d.%d", api.random.Intn(254)+1, api.random.Intn(254)+1),
+			"LineId":             req.getDefault("LineId", fakeEipLine["LineId"].(string)),
+			"BandWidth":          req.getInt("BandWidth", 0),
+			"ChargeType":         req.get("ChargeType"),
+			"ProjectId":          req.getDefault("ProjectId", "0"),
+			"State":              "disassociate",
+			"IpVersion":          "ipv4",
+			"InstanceId":         "",
+			"InstanceType":       "",
+			"NetworkInterfaceId": "",
+			"InternetGatewayId":  "",
+			"BandWidthShareId":   "",
+			"CreateTime":         api.now(),
+		})
+		_ = bandWidth
+		return map[string]interface{}{
+			"AllocationId": eip["AllocationId"],
+			"PublicIp":     eip["PublicIp"],
+		}, nil
+	})
+	api.handle("eip", "DescribeAddresses", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{"AddressesSet": api.list("eip", req, "AllocationId", map[string]string{
+			"network-interface-id": "NetworkInterfaceId",
+			"instance-type":        "InstanceType",
+			"internet-gateway-id":  "InternetGatewayId",
+			"band-width-share-id":  "BandWidthShareId",
+			"line-id":              "LineId",
+			"public-ip":            "PublicIp",
+		})}, nil
+	})
+	api.handle("eip", "ModifyAddress", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		eip, err := api.mustGet("eip", req.get("AllocationId"), "InvalidAllocationId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		if req.get("BandWidth") != "" {
+			eip["BandWidth"] = req.getInt("BandWidth", 0)
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("eip", "AssociateAddress", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		eip, err := api.mustGet("eip", req.get("AllocationId"), "InvalidAllocationId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		if eip["State"] == "associate" {
+			return nil, newFakeApiError(400, "InvalidAction.InUse", "address %s is already associated", eip["AllocationId"])
+		}
+		req.update(eip, "InstanceId", "InstanceType", "NetworkInterfaceId")
+		eip["State"] = "associate"
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("eip", "DisassociateAddress", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		eip, err := api.mustGet("eip", req.get("AllocationId"), "InvalidAllocationId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		eip["InstanceId"], eip["InstanceType"], eip["NetworkInterfaceId"] = "", "", ""
+		eip["State"] = "disassociate"
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("eip", "ReleaseAddress", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		eip, err := api.mustGet("eip", req.get("AllocationId"), "InvalidAllocationId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		if eip["State"] == "associate" {
+			return nil, newFakeApiError(400, "InvalidAction.InUse", "address %s is associated", eip["AllocationId"])
+		}
+		api.remove("eip", eip["AllocationId"].(string))
+		return map[string]interface{}{"Return": true}, nil
+	})
+}
+
+var fakeEipLine = map[string]interface{}{
+	"LineId":   "5fc2595f-1bfd-481b-bf64-2d08f116d800",
+	"LineName": "BGP",
+	"LineType": "BGP",
+}
+
+func (api *fakeKsyunApi) registerKecHandlers() {
+	api.handle("kec", "DescribeInstanceTypeConfigs", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		families := req.filters()["instance-family"]
+		var items []interface{}
+		for _, v := range []struct {
+			instanceType string
+			gpu          int
+			zones        []string
+		}{
+			{"S6.1A", 0, fakeAvailabilityZones},
+			{"S6.2B", 0, fakeAvailabilityZones[:2]},
+			{"S6.4B", 0, fakeAvailabilityZones[1:2]},
+			{"N3.4B", 0, fakeAvailabilityZones},
+			{"S6.8C", 0, fakeAvailabilityZones[:1]},
+			{"P3I.8D", 1, fakeAvailabilityZones[:1]},
+		} {
+			family := strings.Split(v.instanceType, ".")[0]
+			if len(families) > 0 && !fakeStringInSlice(family, families) {
+				continue
+			}
+			cpu, memory := fakeInstanceTypeConfigure(v.instanceType)
+			var zones []interface{}
+			for _, zone := range v.zones {
+				zones = append(zones, map[string]interface{}{"AzCode": zone})
+			}
+			item := map[string]interface{}{
+				"InstanceType":        v.instanceType,
+				"InstanceFamily":      family,
+				"InstanceFamilyName":  family + " family",
+				"CPU":                 cpu,
+				"Memory":              memory,
+				"GPU":                 v.gpu,
+				"LocalDiskSize":       0,
+				"NetworkBandwidth":    "1.5",
+				"AvailabilityZoneSet": zones,
+			}
+			if v.gpu > 0 {
+				item["GpuModel"] = "V100"
+			}
+			items = append(items, item)
+		}
+		return map[string]interface{}{"InstanceTypeConfigSet": items}, nil
+	})
+	api.handle("kec", "RunInstances", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		for _, key := range []string{"ImageId", "InstanceType", "SubnetId"} {
+			if _, err := req.require(key); err != nil {
+				return nil, err
+			}
+		}
+		subnet, err := api.mustGet("subnet", req.get("SubnetId"), "InvalidSubnetId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		var securityGroups []interface{}
+		for _, sgId := range req.indexed("SecurityGroupId") {
+			if _, err = api.mustGet("security_group", sgId, "SecurityGroupNotFound"); err != nil {
+				return nil, err
+			}
+			securityGroups = append(securityGroups, map[string]interface{}{"SecurityGroupId": sgId})
+		}
+		if len(securityGroups) == 0 {
+			return nil, newFakeApiError(400, "MissingParameter", "the parameter SecurityGroupId.N is required")
+		}
+		privateIp := req.getDefault("PrivateIpAddress", fakeSubnetIp(subnet["CidrBlock"].(string), len(api.order["network_interface"])+10))
+		vif := api.create("network_interface", "NetworkInterfaceId", map[string]interface{}{
+			"NetworkInterfaceType": "primary",
+			"VpcId":                subnet["VpcId"],
+			"SubnetId":             subnet["SubnetId"],
+			"PrivateIpAddress":     privateIp,
+			"MacAddress":           fmt.Sprintf("fa:16:3e:%02x:%02x:%02x", api.random.Intn(256), api.random.Intn(256), api.random.Intn(256)),
+			"SecurityGroupSet":     securityGroups,
+			"InstanceType":         "kec",
+			"DNS1":                 "198.18.254.40",
+			"DNS2":                 "198.18.254.41",
+		})
+		cpu, memory := fakeInstanceTypeConfigure(req.get("InstanceType"))
+		var keys []interface{}
+		for _, key := range req.indexed("KeyId") {
+			keys = append(keys, key)
+		}
+		instance := api.create("instance", "InstanceId", map[string]interface{}{
+			"InstanceName":     req.getDefault("InstanceName", "ksc_instance"),
+			"InstanceType":     req.get("InstanceType"),
+			"ImageId":          req.get("ImageId"),
+			"SubnetId":         subnet["SubnetId"],
+			"PrivateIpAddress": privateIp,
+			"ChargeType":       req.get("ChargeType"),
+			"ProjectId":        req.getInt("ProjectId", 0),
+			"HostName":         req.getDefault("HostName", "vm"+strings.Replace(privateIp, ".", "-", -1)),
+			"CreationDate":     api.now(),
+			"InstanceConfigure": map[string]interface{}{
+				"VCPU":     cpu,
+				"MemoryGb": memory,
+			},
+			"InstanceState": map[string]interface{}{"Name": "scheduling"},
+			"SystemDisk": map[string]interface{}{
+				"DiskType": req.getDefault("SystemDisk.DiskType", "Local_SSD"),
+				"DiskSize": req.getInt("SystemDisk.DiskSize", 20),
+			},
+			"KeySet": keys,
+		})
+		if dataGuardId := req.get("DataGuardId"); dataGuardId != "" {
+			if _, err := api.mustGet("data_guard", dataGuardId, "InvalidDataGuardId.NotFound"); err != nil {
+				return nil, err
+			}
+			instance["DataGuardId"] = dataGuardId
+		}
+		if hostId := req.get("DedicatedHostId"); hostId != "" {
+			if _, err := api.mustGet("dedicated_host", hostId, "InvalidDedicatedHostId.NotFound"); err != nil {
+				return nil, err
+			}
+			availableCpu, availableMemory := api.dedicatedHostAvailable(hostId)
+			if cpu > availableCpu || memory > availableMemory {
+				return nil, newFakeApiError(400, "DedicatedHostCapacityNotEnough", "the dedicated host %s has not enough capacity", hostId)
+			}
+			instance["DedicatedHostId"] = hostId
+		}
+		vif["InstanceId"] = instance["InstanceId"]
+		api.syncInstanceNetwork(instance)
+		api.setTransition(instance["InstanceId"].(string), "active")
+		return map[string]interface{}{
+			"InstancesSet": []interface{}{
+				map[string]interface{}{"InstanceId": instance["InstanceId"]},
+			},
+		}, nil
+	})
+	api.handle("kec", "DescribeInstances", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		items := api.list("instance", req, "InstanceId", map[string]string{
+			"subnet-id":     "SubnetId",
+			"instance-type": "InstanceType",
+		})
+		for _, item := range items {
+			id := item.(map[string]interface{})["InstanceId"].(string)
+			api.applyTransition(id, func(next string) {
+				api.resources["instance"][id]["InstanceState"] = map[string]interface{}{"Name": next}
+			})
+		}
+		return map[string]interface{}{
+			"InstancesSet":  items,
+			"InstanceCount": len(items),
+		}, nil
+	})
+	api.handle("kec", "ModifyInstanceAttribute", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		instance, err := api.mustGet("instance", req.get("InstanceId"), "InvalidInstanceId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(instance, "InstanceName", "HostName")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "ModifyInstanceType", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		instance, err := api.mustGet("instance", req.get("InstanceId"), "InvalidInstanceId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(instance, "InstanceType")
+		cpu, memory := fakeInstanceTypeConfigure(req.get("InstanceType"))
+		instance["InstanceConfigure"] = map[string]interface{}{"VCPU": cpu, "MemoryGb": memory}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "ModifyNetworkInterfaceAttribute", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		instance, err := api.mustGet("instance", req.get("InstanceId"), "InvalidInstanceId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		vif, err := api.mustGet("network_interface", req.get("NetworkInterfaceId"), "NetworkInterfaceNotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(vif, "SubnetId", "PrivateIpAddress", "DNS1", "DNS2")
+		if sgIds := req.indexed("SecurityGroupId"); len(sgIds) > 0 {
+			var securityGroups []interface{}
+			for _, sgId := range sgIds {
+				securityGroups = append(securityGroups, map[string]interface{}{"SecurityGroupId": sgId})
+			}
+			vif["SecurityGroupSet"] = securityGroups
+		}
+		api.syncInstanceNetwork(instance)
+		return map[string]interface{}{"Return": true}, nil
+	})
+	for action, states := range map[string][2]string{
+		"StopInstances":   {"stopping", "stopped"},
+		"StartInstances":  {"starting", "active"},
+		"RebootInstances": {"rebooting", "active"},
+	} {
+		states := states
+		api.handle("kec", action, func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+			var result []interface{}
+			for _, id := range req.indexed("InstanceId") {
+				instance, err := api.mustGet("instance", id, "InvalidInstanceId.NotFound")
+				if err != nil {
+					return nil, err
+				}
+				instance["InstanceState"] = map[string]interface{}{"Name": states[0]}
+				api.setTransition(id, states[1])
+				result = append(result, map[string]interface{}{"InstanceId": id, "Return": true})
+			}
+			return map[string]interface{}{"InstancesSet": result}, nil
+		})
+	}
+	api.handle("kec", "TerminateInstances", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		var result []interface{}
+		for _, id := range req.indexed("InstanceId") {
+			if _, err := api.mustGet("instance", id, "InvalidInstanceId.NotFound"); err != nil {
+				return nil, err
+			}
+			for _, vifId := range append([]string{}, api.order["network_interface"]...) {
+				if api.resources["network_interface"][vifId]["InstanceId"] == id {
+					api.remove("network_interface", vifId)
+				}
+			}
+			api.remove("instance", id)
+			delete(api.transitions, id)
+			result = append(result, map[string]interface{}{"InstanceId": id, "Return": true})
+		}
+		return map[string]interface{}{"InstancesSet": result}, nil
+	})
+	api.handle("kec", "CreateDataGuardGroup", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		name, err := req.require("DataGuardName")
+		if err != nil {
+			return nil, err
+		}
+		dataGuard := api.create("data_guard", "DataGuardId", map[string]interface{}{
+			"DataGuardName":     name,
+			"DataGuardCapacity": 20,
+		})
+		return map[string]interface{}{"DataGuardId": dataGuard["DataGuardId"]}, nil
+	})
+	api.handle("kec", "DescribeDataGuardGroup", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		items := api.list("data_guard", req, "DataGuardId", nil)
+		for _, item := range items {
+			dataGuard := item.(map[string]interface{})
+			instances := []interface{}{}
+			for _, id := range api.order["instance"] {
+				instance := api.resources["instance"][id]
+				if instance["DataGuardId"] == dataGuard["DataGuardId"] {
+					instances = append(instances, map[string]interface{}{
+						"InstanceId":   id,
+						"InstanceName": instance["InstanceName"],
+					})
+				}
+			}
+			dataGuard["Instances"] = instances
+		}
+		return map[string]interface{}{"DataGuardsSet": items}, nil
+	})
+	api.handle("kec", "ModifyDataGuardGroups", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		dataGuard, err := api.mustGet("data_guard", req.get("DataGuardId"), "InvalidDataGuardId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(dataGuard, "DataGuardName")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "DeleteDataGuardGroups", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		for _, id := range req.indexed("DataGuardId") {
+			if _, err := api.mustGet("data_guard", id, "InvalidDataGuardId.NotFound"); err != nil {
+				return nil, err
+			}
+			for _, instance := range api.resources["instance"] {
+				if instance["DataGuardId"] == id {
+					return nil, newFakeApiError(400, "DataGuardGroupInUse", "the data guard group %s has instances", id)
+				}
+			}
+			api.remove("data_guard", id)
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "AddVmIntoDataGuard", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		if _, err := api.mustGet("data_guard", req.get("DataGuardId"), "InvalidDataGuardId.NotFound"); err != nil {
+			return nil, err
+		}
+		for _, id := range req.indexed("InstanceId") {
+			instance, err := api.mustGet("instance", id, "InvalidInstanceId.NotFound")
+			if err != nil {
+				return nil, err
+			}
+			if v, ok := instance["DataGuardId"]; ok && v != "" {
+				return nil, newFakeApiError(400, "InstanceInDataGuard", "the instance %s is in the data guard group %s", id, v)
+			}
+			instance["DataGuardId"] = req.get("DataGuardId")
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "RemoveVmFromDataGuard", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		for _, id := range req.indexed("InstanceId") {
+			instance, err := api.mustGet("instance", id, "InvalidInstanceId.NotFound")
+			if err != nil {
+				return nil, err
+			}
+			if instance["DataGuardId"] != req.get("DataGuardId") {
+				return nil, newFakeApiError(400, "InstanceNotInDataGuard", "the instance %s is not in the data guard group", id)
+			}
+			delete(instance, "DataGuardId")
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "CreateDedicatedHosts", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		dedicatedType, err := req.require("DedicatedType")
+		if err != nil {
+			return nil, err
+		}
+		zone, err := req.require("AvailabilityZone")
+		if err != nil {
+			return nil, err
+		}
+		chargeType := req.get("ChargeType")
+		if !fakeStringInSlice(chargeType, []string{"PrePaidByMonth", "Monthly", "PostPaidByDay", "Daily"}) {
+			return nil, newFakeApiError(400, "InvalidParameterValue", "the charge type %s is not supported", chargeType)
+		}
+		if (chargeType == "PrePaidByMonth" || chargeType == "Monthly") && req.get("PurchaseTime") == "" {
+			return nil, newFakeApiError(400, "MissingParameter", "the parameter PurchaseTime is required")
+		}
+		host := api.create("dedicated_host", "DedicatedHostId", map[string]interface{}{
+			"DedicatedHostName":  req.getDefault("DedicatedHostName", "ksc_dedicated_host"),
+			"DedicatedType":      dedicatedType,
+			"DedicatedClusterId": req.get("DedicatedClusterId"),
+			"AvailabilityZone":   zone,
+			"ChargeType":         chargeType,
+			"ProjectId":          req.getInt("ProjectId", 0),
+			"Cpu":                64,
+			"Memory":             256,
+			"Status":             "creating",
+			"CreateTime":         api.now(),
+		})
+		api.setTransition(host["DedicatedHostId"].(string), "available")
+		return map[string]interface{}{
+			"DedicatedHostSet": []interface{}{
+				map[string]interface{}{"DedicatedHostId": host["DedicatedHostId"]},
+			},
+		}, nil
+	})
+	api.handle("kec", "DescribeDedicatedHosts", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		items := api.list("dedicated_host", req, "DedicatedHostId", nil)
+		for _, item := range items {
+			host := item.(map[string]interface{})
+			id := host["DedicatedHostId"].(string)
+			api.applyTransition(id, func(next string) {
+				api.resources["dedicated_host"][id]["Status"] = next
+			})
+			instanceIds := []interface{}{}
+			for _, instanceId := range api.order["instance"] {
+				if api.resources["instance"][instanceId]["DedicatedHostId"] == id {
+					instanceIds = append(instanceIds, instanceId)
+				}
+			}
+			host["InstanceIds"] = instanceIds
+			host["AvailableCpu"], host["AvailableMemory"] = api.dedicatedHostAvailable(id)
+		}
+		return map[string]interface{}{"DedicatedHostSet": items}, nil
+	})
+	api.handle("kec", "RenameDedicatedHost", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		host, err := api.mustGet("dedicated_host", req.get("DedicatedHostId"), "InvalidDedicatedHostId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(host, "DedicatedHostName")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "DeleteDedicatedHost", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		id := req.get("DedicatedHostId")
+		if _, err := api.mustGet("dedicated_host", id, "InvalidDedicatedHostId.NotFound"); err != nil {
+			return nil, err
+		}
+		for _, instance := range api.resources["instance"] {
+			if instance["DedicatedHostId"] == id {
+				return nil, newFakeApiError(400, "DedicatedHostInUse", "the dedicated host %s has instances", id)
+			}
+		}
+		api.remove("dedicated_host", id)
+		delete(api.transitions, id)
+		return map[string]interface{}{"Return": true}, nil
+	})
+}
+
+// dedicatedHostAvailable returns the cpu and memory of the dedicated host not used by the instances on it
+func (api *fakeKsyunApi) dedicatedHostAvailable(hostId string) (int, int) {
+	host := api.resources["dedicated_host"][hostId]
+	cpu, memory := host["Cpu"].(int), host["Memory"].(int)
+	for _, instance := range api.resources["instance"] {
+		if instance["DedicatedHostId"] != hostId {
+			continue
+		}
+		configure := instance["InstanceConfigure"].(map[string]interface{})
+		cpu -= configure["VCPU"].(int)
+		memory -= configure["MemoryGb"].(int)
+	}
+	return cpu, memory
+}
+
+// syncInstanceNetwork copies the network interfaces of instance to its NetworkInterfaceSet
+func (api *fakeKsyunApi) syncInstanceNetwork(instance map[string]interface{}) {
+	var vifs []interface{}
+	for _, id := range api.order["network_interface"] {
+		vif := api.resources["network_interface"][id]
+		if vif["InstanceId"] != instance["InstanceId"] {
+			continue
+		}
+		vifs = append(vifs, map[string]interface{}{
+			"NetworkInterfaceId":   vif["NetworkInterfaceId"],
+			"NetworkInterfaceType": vif["NetworkInterfaceType"],
+			"VpcId":                vif["VpcId"],
+			"SubnetId":             vif["SubnetId"],
+			"MacAddress":           vif["MacAddress"],
+			"PrivateIpAddress":     vif["PrivateIpAddress"],
+			"SecurityGroupSet":     vif["SecurityGroupSet"],
+		})
+		if vif["NetworkInterfaceType"] == "primary" {
+			instance["SubnetId"] = vif["SubnetId"]
+			instance["PrivateIpAddress"] = vif["PrivateIpAddress"]
+		}
+	}
+	instance["NetworkInterfaceSet"] = vifs
+}
+
+func (api *fakeKsyunApi) registerImageHandlers() {
+	api.handle("kec", "CreateImage", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		name, err := req.require("Name")
+		if err != nil {
+			return nil, err
+		}
+		instance, err := api.mustGet("instance", req.get("InstanceId"), "InvalidInstanceId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		image := api.create("image", "ImageId", map[string]interface{}{
+			"Name":          name,
+			"InstanceId":    instance["InstanceId"],
+			"ImageState":    "creating",
+			"CreationDate":  api.now(),
+			"Platform":      "centos-7.5",
+			"ImageSource":   "custom",
+			"IsPublic":      false,
+			"SysDisk":       instance["SystemDisk"].(map[string]interface{})["DiskSize"],
+			"DataDiskIds":   req.indexed("DataDiskIds"),
+			"ShareAccounts": []string{},
+		})
+		api.setTransition(image["ImageId"].(string), "active")
+		return map[string]interface{}{"ImageId": image["ImageId"]}, nil
+	})
+	api.handle("kec", "DescribeImages", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		// the images of the other regions are the copies of the images
+		if req.region != "" && req.region != fakeApiRegion {
+			items := []interface{}{}
+			for _, item := range api.list("image_copy", req, "ImageId", nil) {
+				copied := item.(map[string]interface{})
+				if id := req.get("ImageId"); copied["Region"] == req.region && (id == "" || id == copied["ImageId"]) {
+					items = append(items, copied)
+				}
+			}
+			return map[string]interface{}{"ImagesSet": items}, nil
+		}
+		var items []interface{}
+		for _, item := range api.list("image", req, "ImageId", nil) {
+			image := item.(map[string]interface{})
+			if id := req.get("ImageId"); id != "" && id != image["ImageId"] {
+				continue
+			}
+			id := image["ImageId"].(string)
+			api.applyTransition(id, func(next string) {
+				api.resources["image"][id]["ImageState"] = next
+			})
+			delete(image, "ShareAccounts")
+			items = append(items, image)
+		}
+		return map[string]interface{}{"ImagesSet": items}, nil
+	})
+	api.handle("kec", "ModifyImageAttribute", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		image, err := api.mustGet("image", req.get("ImageId"), "InvalidImageId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(image, "Name")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "ModifyImageSharePermission", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		for _, id := range req.indexed("ImageId") {
+			image, err := api.mustGet("image", id, "InvalidImageId.NotFound")
+			if err != nil {
+				return nil, err
+			}
+			var accounts []string
+			for _, account := range image["ShareAccounts"].([]string) {
+				if !fakeStringInSlice(account, req.indexed("AccountId")) {
+					accounts = append(accounts, account)
+				}
+			}
+			if req.get("Permission") == "share" {
+				accounts = append(accounts, req.indexed("AccountId")...)
+			}
+			image["ShareAccounts"] = accounts
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("kec", "DescribeImageSharePermission", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		image, err := api.mustGet("image", req.get("ImageId"), "InvalidImageId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		var accounts []interface{}
+		for _, account := range image["ShareAccounts"].([]string) {
+			accounts = append(accounts, map[string]interface{}{"AccountId": account})
+		}
+		return map[string]interface{}{"AccountIdSet": accounts}, nil
+	})
+	api.handle("kec", "CopyImage", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		var images []interface{}
+		for _, id := range req.indexed("ImageId") {
+			if _, err := api.mustGet("image", id, "InvalidImageId.NotFound"); err != nil {
+				return nil, err
+			}
+			for _, region := range req.indexed("DestinationRegion") {
+				copied := api.create("image_copy", "ImageId", map[string]interface{}{
+					"SourceImageId": id,
+					"Region":        region,
+					"Name":          req.get("DestinationImageName"),
+				})
+				images = append(images, map[string]interface{}{"ImageId": copied["ImageId"], "Region": region})
+			}
+		}
+		return map[string]interface{}{"Return": true, "ImagesSet": images}, nil
+	})
+	api.handle("kec", "RemoveImages", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		for _, id := range req.indexed("ImageId") {
+			// the copies are removed by the same action in their regions
+			kind := "image"
+			if copied, ok := api.resources["image_copy"][id]; ok && copied["Region"] == req.region {
+				kind = "image_copy"
+			}
+			if _, err := api.mustGet(kind, id, "InvalidImageId.NotFound"); err != nil {
+				return nil, err
+			}
+			api.remove(kind, id)
+			delete(api.transitions, id)
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+}
+
+func (api *fakeKsyunApi) registerEbsHandlers() {
+	api.handle("ebs", "DescribeVolumes", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		items := api.list("volume", req, "VolumeId", nil)
+		return map[string]interface{}{"Volumes": items, "TotalCount": len(items)}, nil
+	})
+	api.handle("ebs", "CreateSnapshot", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		volume, err := api.mustGet("volume", req.get("VolumeId"), "InvalidVolumeId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		snapshot := api.create("snapshot", "SnapshotId", map[string]interface{}{
+			"SnapshotName":     req.getDefault("SnapshotName", "snapshot"),
+			"SnapshotDesc":     req.get("SnapshotDesc"),
+			"VolumeId":         volume["VolumeId"],
+			"VolumeCategory":   volume["VolumeCategory"],
+			"AvailabilityZone": volume["AvailabilityZone"],
+			"Size":             volume["Size"],
+			"SnapshotStatus":   "creating",
+			"SnapshotType":     "LocalSnapShot",
+			"Progress":         "0%",
+			"CreateTime":       api.now(),
+		})
+		api.setTransition(snapshot["SnapshotId"].(string), "available")
+		return map[string]interface{}{"SnapshotId": snapshot["SnapshotId"]}, nil
+	})
+	api.handle("ebs", "DescribeSnapshots", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		var items []interface{}
+		for _, item := range api.list("snapshot", req, "SnapshotId", nil) {
+			snapshot := item.(map[string]interface{})
+			if id := req.get("SnapshotId"); id != "" && id != snapshot["SnapshotId"] {
+				continue
+			}
+			if volumeId := req.get("VolumeId"); volumeId != "" && volumeId != snapshot["VolumeId"] {
+				continue
+			}
+			id := snapshot["SnapshotId"].(string)
+			api.applyTransition(id, func(next string) {
+				api.resources["snapshot"][id]["SnapshotStatus"] = next
+				api.resources["snapshot"][id]["Progress"] = "100%"
+			})
+			items = append(items, snapshot)
+		}
+		return map[string]interface{}{"Snapshots": items}, nil
+	})
+	api.handle("ebs", "ModifySnapshot", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		snapshot, err := api.mustGet("snapshot", req.get("SnapshotId"), "InvalidSnapshotId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(snapshot, "SnapshotName", "SnapshotDesc")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("ebs", "DeleteSnapshot", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		if _, err := api.mustGet("snapshot", req.get("SnapshotId"), "InvalidSnapshotId.NotFound"); err != nil {
+			return nil, err
+		}
+		api.remove("snapshot", req.get("SnapshotId"))
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("ebs", "CreateAutoSnapshotPolicy", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		name, err := req.require("AutoSnapshotPolicyName")
+		if err != nil {
+			return nil, err
+		}
+		policy := api.create("auto_snapshot_policy", "AutoSnapshotPolicyId", map[string]interface{}{
+			"AutoSnapshotPolicyName": name,
+			"AutoSnapshotDate":       fakeIntSlice(req.indexed("AutoSnapshotDate")),
+			"AutoSnapshotTime":       fakeIntSlice(req.indexed("AutoSnapshotTime")),
+			"RetentionTime":          req.getInt("RetentionTime", 7),
+			"CreateTime":             api.now(),
+		})
+		return map[string]interface{}{"AutoSnapshotPolicyId": policy["AutoSnapshotPolicyId"]}, nil
+	})
+	api.handle("ebs", "DescribeAutoSnapshotPolicy", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{"AutoSnapshotPolicySet": api.list("auto_snapshot_policy", req, "AutoSnapshotPolicyId", nil)}, nil
+	})
+	api.handle("ebs", "ModifyAutoSnapshotPolicy", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		policy, err := api.mustGet("auto_snapshot_policy", req.get("AutoSnapshotPolicyId"), "InvalidAutoSnapshotPolicyId.NotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(policy, "AutoSnapshotPolicyName")
+		for _, field := range []string{"AutoSnapshotDate", "AutoSnapshotTime"} {
+			if values := req.indexed(field); len(values) > 0 {
+				policy[field] = fakeIntSlice(values)
+			}
+		}
+		if req.get("RetentionTime") != "" {
+			policy["RetentionTime"] = req.getInt("RetentionTime", 7)
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("ebs", "DeleteAutoSnapshotPolicy", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		for _, id := range req.indexed("AutoSnapshotPolicyId") {
+			if _, err := api.mustGet("auto_snapshot_policy", id, "InvalidAutoSnapshotPolicyId.NotFound"); err != nil {
+				return nil, err
+			}
+			for _, volume := range api.resources["volume"] {
+				if volume["AutoSnapshotPolicyId"] == id {
+					return nil, newFakeApiError(400, "AutoSnapshotPolicyInUse", "the auto snapshot policy %s is applied to volumes", id)
+				}
+			}
+			api.remove("auto_snapshot_policy", id)
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("ebs", "ApplyAutoSnapshotPolicy", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		if _, err := api.mustGet("auto_snapshot_policy", req.get("AutoSnapshotPolicyId"), "InvalidAutoSnapshotPolicyId.NotFound"); err != nil {
+			return nil, err
+		}
+		for _, id := range req.indexed("VolumeId") {
+			volume, err := api.mustGet("volume", id, "InvalidVolumeId.NotFound")
+			if err != nil {
+				return nil, err
+			}
+			volume["AutoSnapshotPolicyId"] = req.get("AutoSnapshotPolicyId")
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("ebs", "CancelAutoSnapshotPolicy", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		for _, id := range req.indexed("VolumeId") {
+			volume, err := api.mustGet("volume", id, "InvalidVolumeId.NotFound")
+			if err != nil {
+				return nil, err
+			}
+			delete(volume, "AutoSnapshotPolicyId")
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+}
+
+func (api *fakeKsyunApi) registerSlbHandlers() {
+	api.handle("slb", "CreateLoadBalancer", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		vpcId := req.get("VpcId")
+		if _, err := api.mustGet("vpc", vpcId, "InvalidVpcId.NotFound"); err != nil {
+			return nil, err
+		}
+		lbType := req.getDefault("Type", "public")
+		lb := map[string]interface{}{
+			"VpcId":             vpcId,
+			"LoadBalancerName":  req.get("LoadBalancerName"),
+			"Type":              lbType,
+			"SubnetId":          req.get("SubnetId"),
+			"PrivateIpAddress":  req.get("PrivateIpAddress"),
+			"ProjectId":         req.getDefault("ProjectId", "0"),
+			"LoadBalancerState": "start",
+			"IpVersion":         req.getDefault("IpVersion", "ipv4"),
+			"State":             "associate",
+			"IsWaf":             false,
+			"CreateTime":        api.now(),
+			"attributes":        map[string]interface{}{},
+		}
+		// the state of load balancer is AdminStateUp on creating
+		if req.get("AdminStateUp") == "false" {
+			lb["LoadBalancerState"] = "stop"
+		}
+		if lbType == "internal" {
+			subnet, err := api.mustGet("subnet", req.get("SubnetId"), "InvalidSubnetId.NotFound")
+			if err != nil {
+				return nil, err
+			}
+			if lb["PrivateIpAddress"] == "" {
+				lb["PrivateIpAddress"] = fakeSubnetIp(subnet["CidrBlock"].(string), len(api.order["slb"])+100)
+			}
+		} else {
+			lb["PublicIp"] = fmt.Sprintf("120.131.%d.%d", api.random.Intn(254)+1, api.random.Intn(254)+1)
+		}
+		lb = api.create("slb", "LoadBalancerId", lb)
+		return map[string]interface{}{
+			"LoadBalancerId": lb["LoadBalancerId"],
+			"PublicIp":       lb["PublicIp"],
+		}, nil
+	})
+	api.handle("slb", "DescribeLoadBalancers", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		items := api.list("slb", req, "LoadBalancerId", map[string]string{
+			"vpc-id": "VpcId",
+		})
+		for _, item := range items {
+			delete(item.(map[string]interface{}), "attributes")
+		}
+		return map[string]interface{}{"LoadBalancerDescriptions": items}, nil
+	})
+	api.handle("slb", "DescribeLoadBalancerAttributes", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		lb, err := api.mustGet("slb", req.get("LoadBalancerId"), "LoadBalancerNotFound")
+		if err != nil {
+			return nil, err
+		}
+		var attributes []interface{}
+		for _, key := range sortedFakeKeys(fakeStringMap(lb["attributes"])) {
+			attributes = append(attributes, map[string]interface{}{
+				"Key":   key,
+				"Value": lb["attributes"].(map[string]interface{})[key],
+			})
+		}
+		return map[string]interface{}{"LoadBalancerAttributeSet": attributes}, nil
+	})
+	api.handle("slb", "ModifyLoadBalancerAttributes", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		lb, err := api.mustGet("slb", req.get("LoadBalancerId"), "LoadBalancerNotFound")
+		if err != nil {
+			return nil, err
+		}
+		for i := 1; req.get("Attributes.member."+strconv.Itoa(i)+".Key") != ""; i++ {
+			prefix := "Attributes.member." + strconv.Itoa(i)
+			lb["attributes"].(map[string]interface{})[req.get(prefix+".Key")] = req.get(prefix + ".Value")
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("slb", "ModifyLoadBalancer", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		lb, err := api.mustGet("slb", req.get("LoadBalancerId"), "LoadBalancerNotFound")
+		if err != nil {
+			return nil, err
+		}
+		req.update(lb, "LoadBalancerName", "LoadBalancerState")
+		return map[string]interface{}{"Return": true}, nil
+	})
+	api.handle("slb", "DeleteLoadBalancer", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		lbId := req.get("LoadBalancerId")
+		if _, err := api.mustGet("slb", lbId, "LoadBalancerNotFound"); err != nil {
+			return nil, err
+		}
+		api.remove("slb", lbId)
+		return map[string]interface{}{"Return": true}, nil
+	})
+}
+
+func (api *fakeKsyunApi) registerTagHandlers() {
+	api.handle("tagv2", "ListTagsByResourceIds", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		resourceType, err := req.require("ResourceType")
+		if err != nil {
+			return nil, err
+		}
+		tags := []interface{}{}
+		for _, id := range strings.Split(req.get("ResourceUuids"), ",") {
+			for _, key := range sortedFakeKeys(api.tags[id]) {
+				tags = append(tags, map[string]interface{}{
+					"ResourceUuid": id,
+					"ResourceType": resourceType,
+					"TagKey":       key,
+					"TagValue":     api.tags[id][key],
+				})
+			}
+		}
+		return map[string]interface{}{"Tags": tags}, nil
+	})
+	api.handle("tagv2", "ReplaceResourcesTags", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		if _, err := req.require("ResourceType"); err != nil {
+			return nil, err
+		}
+		tags := make(map[string]string)
+		for _, key := range req.keys() {
+			if strings.HasPrefix(key, "Tag_") && strings.HasSuffix(key, "_Key") {
+				tags[req.get(key)] = req.get(strings.TrimSuffix(key, "_Key") + "_Value")
+			}
+		}
+		var ids []string
+		if replaceTags, ok := req.body["ReplaceTags"].([]interface{}); ok {
+			for _, v := range replaceTags {
+				if m, ok := v.(map[string]interface{}); ok {
+					ids = append(ids, strings.Split(fmt.Sprintf("%v", m["ResourceUuids"]), ",")...)
+				}
+			}
+		}
+		if v := req.get("ResourceUuids"); v != "" {
+			ids = append(ids, strings.Split(v, ",")...)
+		}
+		if len(ids) == 0 {
+			return nil, newFakeApiError(400, "MissingParameter", "the parameter ResourceUuids is required")
+		}
+		for _, id := range ids {
+			api.tags[id] = tags
+		}
+		return map[string]interface{}{"Result": true}, nil
+	})
+}
+
+func (api *fakeKsyunApi) registerIamHandlers() {
+	api.handle("iam", "GetAccountAllProjectList", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return map[string]interface{}{
+			"ListProjectResult": map[string]interface{}{
+				"ProjectList": []interface{}{
+					map[string]interface{}{"ProjectId": 0, "ProjectName": "default"},
+				},
+			},
+		}, nil
+	})
+	api.handle("iam", "UpdateInstanceProjectId", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		id := req.get("InstanceId")
+		for _, items := range api.resources {
+			if item, ok := items[id]; ok {
+				// the services return the project id as they created it, some of them as a string
+				if _, ok := item["ProjectId"].(string); ok {
+					item["ProjectId"] = req.getDefault("ProjectId", "0")
+				} else {
+					item["ProjectId"] = req.getInt("ProjectId", 0)
+				}
+				return map[string]interface{}{"Result": true}, nil
+			}
+		}
+		return nil, newFakeApiError(404, "InstanceNotFound", "the instance %s is not found", id)
+	})
+}
+
+// mustGet returns the item of kind or a not found error with code
+func (api *fakeKsyunApi) mustGet(kind, id, code string) (map[string]interface{}, error) {
+	if id == "" {
+		return nil, newFakeApiError(400, "MissingParameter", "the id of %s is required", kind)
+	}
+	item, ok := api.get(kind, id)
+	if !ok {
+		return nil, newFakeApiError(404, code, "the specified %s %s is not found", kind, id)
+	}
+	return item, nil
+}
+
+// checkDependency returns DependencyViolation if any item of kinds references id by field
+func (api *fakeKsyunApi) checkDependency(id, field string, kinds ...string) error {
+	for _, kind := range kinds {
+		for _, v := range api.order[kind] {
+			if api.resources[kind][v][field] == id {
+				return newFakeApiError(400, "DependencyViolation", "%s is in use by %s %s", id, kind, v)
+			}
+		}
+	}
+	return nil
+}
+
+// securityGroupEntry returns the entry by SecurityGroupEntryId, which is unique among all the security groups
+func (api *fakeKsyunApi) securityGroupEntry(req *fakeApiRequest) (map[string]interface{}, map[string]interface{}, error) {
+	entryId, err := req.require("SecurityGroupEntryId")
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, id := range api.order["security_group"] {
+		sg := api.resources["security_group"][id]
+		if sgId := req.get("SecurityGroupId"); sgId != "" && sgId != id {
+			continue
+		}
+		for _, entry := range sg["SecurityGroupEntrySet"].([]interface{}) {
+			if entry.(map[string]interface{})["SecurityGroupEntryId"] == entryId {
+				return sg, entry.(map[string]interface{}), nil
+			}
+		}
+	}
+	return nil, nil, newFakeApiError(404, "SecurityGroupEntryNotFound", "the specified security group entry %s is not found", entryId)
+}
+
+// fakeInstanceTypeConfigure returns the cpu and memory of instance type such as S6.2B
+func fakeInstanceTypeConfigure(instanceType string) (int, int) {
+	cpu, memory := 1, 1
+	parts := strings.Split(instanceType, ".")
+	if len(parts) == 2 && len(parts[1]) > 1 {
+		if v, err := strconv.Atoi(parts[1][:len(parts[1])-1]); err == nil {
+			cpu = v
+		}
+		switch parts[1][len(parts[1])-1] {
+		case 'A':
+			memory = cpu
+		case 'B':
+			memory = cpu * 2
+		case 'C':
+			memory = cpu * 4
+		case 'D':
+			memory = cpu * 8
+		}
+	}
+	return cpu, memory
+}
+
+func fakeSubnetIp(cidr string, offset int) string {
+	prefix := cidr[:strings.LastIndex(strings.Split(cidr, "/")[0], ".")]
+	return fmt.Sprintf("%s.%d", prefix, offset%250+2)
+}
+
+func fakeAvailableIpNumber(cidr string) int {
+	parts := strings.Split(cidr, "/")
+	if len(parts) != 2 {
+		return 0
+	}
+	bits, err := strconv.Atoi(parts[1])
+	if err != nil || bits > 30 {
+		return 0
+	}
+	return (1 << uint(32-bits)) - 3
+}
+
+func fakeStringMap(v interface{}) map[string]string {
+	result := make(map[string]string)
+	if m, ok := v.(map[string]interface{}); ok {
+		for k, value := range m {
+			result[k] = fmt.Sprintf("%v", value)
+		}
+	}
+	return result
+}
+
+func fakeIntSlice(values []string) []int {
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		i, _ := strconv.Atoi(v)
+		result = append(result, i)
+	}
+	return result
+}
@@ -0,0 +1,543 @@
+package ksyun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+const (
+	fakeApiAccessKey = "AKLTfakeaccesskey"
+	fakeApiSecretKey = "fakesecretkey"
+	fakeApiRegion    = "cn-beijing-6"
+)
+
+// fakeApiServices is the services whose endpoints are pointed to the fake server
+var fakeApiServices = []string{"vpc", "eip", "kec", "ebs", "slb", "tag", "iam"}
+
+// fakeApiWaiterTiming is the timing of the state waiters on the fake server, which changes the states on the next describe
+var fakeApiWaiterTiming = stateWaiterTiming{
+	delay:        time.Millisecond,
+	pollInterval: 5 * time.Millisecond,
+	minTimeout:   5 * time.Millisecond,
+}
+
+// fakeKsyunApi is an in-process http server speaking the ksyun openapi protocol,
+// it verifies the v4 signature of every request and keeps the resources in memory,
+// so the resources can be tested with resource.UnitTest without credentials and network
+type fakeKsyunApi struct {
+	t        *testing.T
+	server   *httptest.Server
+	mu       sync.Mutex
+	random   *rand.Rand
+	seq      int
+	handlers map[string]fakeApiHandler
+
+	// resources is indexed by kind and id, order keeps the creation order of each kind
+	resources map[string]map[string]map[string]interface{}
+	order     map[string][]string
+	// transitions is the next state of resource which is in an intermediate state
+	transitions map[string]string
+	tags        map[string]map[string]string
+	requests    []fakeApiRequest
+}
+
+type fakeApiRequest struct {
+	service string
+	region  string
+	action  string
+	params  url.Values
+	body    map[string]interface{}
+}
+
+type fakeApiHandler func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error)
+
+type fakeApiError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *fakeApiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.status, e.code, e.message)
+}
+
+func newFakeApiError(status int, code string, format string, a ...interface{}) error {
+	return &fakeApiError{
+		status:  status,
+		code:    code,
+		message: fmt.Sprintf(format, a...),
+	}
+}
+
+// newFakeKsyunApi starts the fake server, the test must close it by defer api.server.Close()
+func newFakeKsyunApi(t *testing.T) *fakeKsyunApi {
+	api := &fakeKsyunApi{
+		t:           t,
+		random:      rand.New(rand.NewSource(1)),
+		handlers:    make(map[string]fakeApiHandler),
+		resources:   make(map[string]map[string]map[string]interface{}),
+		order:       make(map[string][]string),
+		transitions: make(map[string]string),
+		tags:        make(map[string]map[string]string),
+	}
+	api.registerVpcHandlers()
+	api.registerEipHandlers()
+	api.registerKecHandlers()
+	api.registerImageHandlers()
+	api.registerEbsHandlers()
+	api.registerSlbHandlers()
+	api.registerTagHandlers()
+	api.registerIamHandlers()
+	api.server = httptest.NewServer(api)
+	return api
+}
+
+func (api *fakeKsyunApi) handle(service, action string, handler fakeApiHandler) {
+	api.handlers[service+"/"+action] = handler
+}
+
+// providers returns a new provider for each test, the provider must be configured by providerConfig,
+// its state waiters poll the fake server every few milliseconds instead of the intervals of the real api
+func (api *fakeKsyunApi) providers() map[string]terraform.ResourceProvider {
+	provider := Provider().(*schema.Provider)
+	configure := provider.ConfigureFunc
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		meta, err := configure(d)
+		if err != nil {
+			return meta, err
+		}
+		meta.(*KsyunClient).waiterTiming = &fakeApiWaiterTiming
+		return meta, err
+	}
+	return map[string]terraform.ResourceProvider{
+		"ksyun": provider,
+	}
+}
+
+// providerConfig returns the provider block which points all the supported services to the fake server
+func (api *fakeKsyunApi) providerConfig() string {
+	var endpoints []string
+	for _, service := range fakeApiServices {
+		endpoints = append(endpoints, fmt.Sprintf("    %s = %q", service, api.server.URL))
+	}
+	return fmt.Sprintf(`
+provider "ksyun" {
+  access_key  = %q
+  secret_key  = %q
+  region      = %q
+  max_retries = 0
+  endpoints {
+%s
+  }
+}
+`, fakeApiAccessKey, fakeApiSecretKey, fakeApiRegion, strings.Join(endpoints, "\n"))
+}
+
+// config returns the provider block followed by the resource config
+func (api *fakeKsyunApi) config(config string) string {
+	return api.providerConfig() + config
+}
+
+func (api *fakeKsyunApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestId := api.uuid()
+	body, _ := ioutil.ReadAll(r.Body)
+	req, err := api.parseRequest(r, body)
+	if err == nil {
+		err = api.verifySignature(r, body, req)
+	}
+	var resp map[string]interface{}
+	if err == nil {
+		resp, err = api.dispatch(req)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		apiErr, ok := err.(*fakeApiError)
+		if !ok {
+			apiErr = &fakeApiError{status: 500, code: "InternalError", message: err.Error()}
+		}
+		w.WriteHeader(apiErr.status)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"RequestID": requestId,
+			"Error": map[string]interface{}{
+				"Code":    apiErr.code,
+				"Message": apiErr.message,
+			},
+		})
+		return
+	}
+	resp["RequestId"] = requestId
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (api *fakeKsyunApi) parseRequest(r *http.Request, body []byte) (*fakeApiRequest, error) {
+	req := &fakeApiRequest{
+		params: r.URL.Query(),
+	}
+	contentType := strings.ToLower(r.Header.Get("Content-Type"))
+	switch {
+	case strings.Contains(contentType, "application/json"):
+		if len(body) > 0 {
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+			if err := decoder.Decode(&req.body); err != nil {
+				return nil, newFakeApiError(400, "MalformedBody", "invalid json body, %s", err)
+			}
+		}
+	case strings.Contains(contentType, "x-www-form-urlencoded"):
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, newFakeApiError(400, "MalformedBody", "invalid form body, %s", err)
+		}
+		for k, v := range form {
+			req.params[k] = v
+		}
+	}
+	req.action = req.params.Get("Action")
+	if req.action == "" {
+		return nil, newFakeApiError(400, "MissingParameter", "the Action parameter is required")
+	}
+	return req, nil
+}
+
+// verifySignature signs the received request again with the fake credentials and compares the signature,
+// the service of request comes from the credential scope of the authorization header
+func (api *fakeKsyunApi) verifySignature(r *http.Request, body []byte, req *fakeApiRequest) error {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		return newFakeApiError(401, "MissingAuthenticationToken", "the request must be signed")
+	}
+	fields := make(map[string]string)
+	for _, field := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	scope := strings.Split(fields["Credential"], "/")
+	if len(scope) != 5 {
+		return newFakeApiError(401, "IncompleteSignature", "invalid credential scope %q", fields["Credential"])
+	}
+	if scope[0] != fakeApiAccessKey {
+		return newFakeApiError(401, "InvalidAccessKeyId", "the access key %q does not exist", scope[0])
+	}
+	region, service := scope[2], scope[3]
+	signTime, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return newFakeApiError(401, "IncompleteSignature", "invalid X-Amz-Date %q", r.Header.Get("X-Amz-Date"))
+	}
+
+	signed, _ := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	for _, h := range strings.Split(fields["SignedHeaders"], ";") {
+		if h != "host" {
+			signed.Header[http.CanonicalHeaderKey(h)] = r.Header[http.CanonicalHeaderKey(h)]
+		}
+	}
+	signer := v4.NewSigner(credentials.NewStaticCredentials(fakeApiAccessKey, fakeApiSecretKey, ""))
+	if _, err = signer.Sign(signed, bytes.NewReader(body), service, region, signTime); err != nil {
+		return err
+	}
+	if signed.Header.Get("Authorization") != auth {
+		return newFakeApiError(403, "SignatureDoesNotMatch", "the request signature does not match")
+	}
+	req.service = service
+	req.region = region
+	return nil
+}
+
+func (api *fakeKsyunApi) dispatch(req *fakeApiRequest) (map[string]interface{}, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.requests = append(api.requests, *req)
+	handler, ok := api.handlers[req.service+"/"+req.action]
+	if !ok {
+		return nil, newFakeApiError(400, "InvalidAction", "the action %s of %s is not supported", req.action, req.service)
+	}
+	if req.params.Get("DryRun") == "true" {
+		return nil, newFakeApiError(412, "DryRunOperation", "request would have succeeded, but DryRun flag is set")
+	}
+	return handler(api, req)
+}
+
+// uuid returns a random but reproducible id in the format of ksyun resource id
+func (api *fakeKsyunApi) uuid() string {
+	b := make([]byte, 16)
+	api.random.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func (api *fakeKsyunApi) now() string {
+	return time.Now().UTC().Format("2006-01-02 15:04:05")
+}
+
+// create stores the item of kind with the generated id and returns it
+func (api *fakeKsyunApi) create(kind, idField string, item map[string]interface{}) map[string]interface{} {
+	id := api.uuid()
+	item[idField] = id
+	if _, ok := api.resources[kind]; !ok {
+		api.resources[kind] = make(map[string]map[string]interface{})
+	}
+	api.resources[kind][id] = item
+	api.order[kind] = append(api.order[kind], id)
+	return item
+}
+
+func (api *fakeKsyunApi) get(kind, id string) (map[string]interface{}, bool) {
+	item, ok := api.resources[kind][id]
+	return item, ok
+}
+
+func (api *fakeKsyunApi) remove(kind, id string) {
+	delete(api.resources[kind], id)
+	delete(api.tags, id)
+	for i, v := range api.order[kind] {
+		if v == id {
+			api.order[kind] = append(api.order[kind][:i], api.order[kind][i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the items of kind in creation order, filtered by the ids and the Filter.N parameters,
+// the filter name is mapped to the item field by filterFields
+func (api *fakeKsyunApi) list(kind string, req *fakeApiRequest, idField string, filterFields map[string]string) []interface{} {
+	ids := req.indexed(idField)
+	filters := req.filters()
+	var items []interface{}
+	for _, id := range api.order[kind] {
+		item := api.resources[kind][id]
+		if len(ids) > 0 && !fakeStringInSlice(id, ids) {
+			continue
+		}
+		matched := true
+		for name, values := range filters {
+			field, ok := filterFields[name]
+			if !ok {
+				continue
+			}
+			if !fakeStringInSlice(fmt.Sprintf("%v", item[field]), values) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			items = append(items, copyFakeItem(item))
+		}
+	}
+	if items == nil {
+		items = []interface{}{}
+	}
+	return items
+}
+
+// setTransition makes the item stay in state until it has been described once, then moves it to next
+func (api *fakeKsyunApi) setTransition(id, next string) {
+	api.transitions[id] = next
+}
+
+func (api *fakeKsyunApi) applyTransition(id string, apply func(next string)) {
+	if next, ok := api.transitions[id]; ok {
+		delete(api.transitions, id)
+		apply(next)
+	}
+}
+
+// exists reports whether the resource of kind is still stored in the fake server
+func (api *fakeKsyunApi) exists(kind, id string) bool {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	_, ok := api.get(kind, id)
+	return ok
+}
+
+func (api *fakeKsyunApi) called(service, action string) int {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	count := 0
+	for _, req := range api.requests {
+		if req.service == service && req.action == action {
+			count++
+		}
+	}
+	return count
+}
+
+func (api *fakeKsyunApi) checkExists(kind, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		if !api.exists(kind, rs.Primary.ID) {
+			return fmt.Errorf("%s %s not exist in fake api", kind, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func (api *fakeKsyunApi) checkDestroy(kind, resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			if api.exists(kind, rs.Primary.ID) {
+				return fmt.Errorf("%s %s still exist in fake api", kind, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// indexed returns the values of the parameters key.1, key.2 ...
+func (req *fakeApiRequest) indexed(key string) []string {
+	var values []string
+	for i := 1; ; i++ {
+		v, ok := req.params[key+"."+strconv.Itoa(i)]
+		if !ok {
+			return values
+		}
+		values = append(values, v[0])
+	}
+}
+
+// filters returns the values of Filter.N.Name and Filter.N.Value.M parameters
+func (req *fakeApiRequest) filters() map[string][]string {
+	filters := make(map[string][]string)
+	for i := 1; ; i++ {
+		name := req.params.Get("Filter." + strconv.Itoa(i) + ".Name")
+		if name == "" {
+			return filters
+		}
+		filters[name] = req.indexed("Filter." + strconv.Itoa(i) + ".Value")
+	}
+}
+
+func (req *fakeApiRequest) get(key string) string {
+	if v := req.params.Get(key); v != "" {
+		return v
+	}
+	if v, ok := req.body[key]; ok && v != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return ""
+}
+
+// keys returns the names of all the parameters in query, form and json body
+func (req *fakeApiRequest) keys() []string {
+	var keys []string
+	for k := range req.params {
+		keys = append(keys, k)
+	}
+	for k := range req.body {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (req *fakeApiRequest) getDefault(key, defaultValue string) string {
+	if v := req.get(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+func (req *fakeApiRequest) getInt(key string, defaultValue int) int {
+	if v, err := strconv.Atoi(req.get(key)); err == nil {
+		return v
+	}
+	return defaultValue
+}
+
+func (req *fakeApiRequest) getBool(key string) bool {
+	return req.get(key) == "true"
+}
+
+// require returns the value of parameter key or a MissingParameter error
+func (req *fakeApiRequest) require(key string) (string, error) {
+	v := req.get(key)
+	if v == "" {
+		return "", newFakeApiError(400, "MissingParameter", "the parameter %s is required", key)
+	}
+	return v, nil
+}
+
+// update sets the item fields from the parameters which are present in request
+func (req *fakeApiRequest) update(item map[string]interface{}, fields ...string) {
+	for _, field := range fields {
+		if v := req.get(field); v != "" {
+			item[field] = v
+		}
+	}
+}
+
+func copyFakeItem(item map[string]interface{}) map[string]interface{} {
+	b, _ := json.Marshal(item)
+	var result map[string]interface{}
+	_ = json.Unmarshal(b, &result)
+	return result
+}
+
+func sortedFakeKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fakeStringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFakeKsyunApi_signature(t *testing.T) {
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	for secretKey, code := range map[string]string{
+		fakeApiSecretKey:       "",
+		"wrongsecret":          "SignatureDoesNotMatch",
+		fakeApiSecretKey + "x": "SignatureDoesNotMatch",
+	} {
+		config := Config{
+			AccessKey: fakeApiAccessKey,
+			SecretKey: secretKey,
+			Region:    fakeApiRegion,
+			Endpoints: map[string]string{"vpc": api.server.URL},
+		}
+		client, err := config.Client()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.vpcconn.DescribeVpcs(&map[string]interface{}{})
+		if code == "" && err != nil {
+			t.Errorf("request signed by %s: %s", secretKey, err)
+		}
+		if code != "" && (err == nil || !strings.Contains(err.Error(), code)) {
+			t.Errorf("request signed by %s: expected %s, got %v", secretKey, code, err)
+		}
+	}
+}
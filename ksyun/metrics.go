@@ -0,0 +1,319 @@
+package ksyun
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metricsFileEnv is the file the api call metrics are written to when the provider exits,
+// the file ending with .prom is written in prometheus text format, otherwise in json
+const metricsFileEnv = "KSYUN_METRICS_FILE"
+
+// metricsLockTimeout is how long a provider process waits for the lock of the metrics file,
+// a lock older than it is left by a killed process and is taken over
+const metricsLockTimeout = 30 * time.Second
+
+// latencyBuckets is the upper bounds in seconds of the api call latency histogram
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// defaultApiMetrics collects the api calls of all the clients in the provider process
+var defaultApiMetrics = newApiMetrics()
+
+type apiMetrics struct {
+	mu      sync.Mutex
+	actions map[string]*actionMetrics
+}
+
+type actionMetrics struct {
+	Service      string          `json:"service"`
+	Action       string          `json:"action"`
+	Count        int64           `json:"count"`
+	Errors       int64           `json:"errors"`
+	Retries      int64           `json:"retries"`
+	LatencySum   float64         `json:"latency_seconds_sum"`
+	LatencyCount []latencyBucket `json:"latency_buckets"`
+}
+
+// latencyBucket is the cumulative count of the calls whose latency is less than or equal to Le seconds
+type latencyBucket struct {
+	Le    string `json:"le"`
+	Count int64  `json:"count"`
+}
+
+type apiMetricsReport struct {
+	// TerraformPid is the terraform process running the provider, the reports of the same run are merged
+	TerraformPid int              `json:"terraform_pid"`
+	Actions      []*actionMetrics `json:"actions"`
+}
+
+func newApiMetrics() *apiMetrics {
+	return &apiMetrics{
+		actions: make(map[string]*actionMetrics),
+	}
+}
+
+func newActionMetrics(service, action string) *actionMetrics {
+	m := &actionMetrics{
+		Service: service,
+		Action:  action,
+	}
+	for _, le := range latencyBuckets {
+		m.LatencyCount = append(m.LatencyCount, latencyBucket{Le: strconv.FormatFloat(le, 'f', -1, 64)})
+	}
+	m.LatencyCount = append(m.LatencyCount, latencyBucket{Le: "+Inf"})
+	return m
+}
+
+func (m *apiMetrics) action(service, action string) *actionMetrics {
+	key := service + "/" + action
+	if _, ok := m.actions[key]; !ok {
+		m.actions[key] = newActionMetrics(service, action)
+	}
+	return m.actions[key]
+}
+
+// observe records an api call of service, retries is the retry count of the sdk retryer for the call
+func (m *apiMetrics) observe(service, action string, latency time.Duration, failed bool, retries int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	metrics := m.action(service, action)
+	metrics.Count++
+	if failed {
+		metrics.Errors++
+	}
+	metrics.Retries += int64(retries)
+	seconds := latency.Seconds()
+	metrics.LatencySum += seconds
+	for i, le := range latencyBuckets {
+		if seconds <= le {
+			metrics.LatencyCount[i].Count++
+		}
+	}
+	metrics.LatencyCount[len(latencyBuckets)].Count++
+}
+
+func (m *apiMetrics) report() *apiMetricsReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	report := &apiMetricsReport{TerraformPid: os.Getppid()}
+	for _, metrics := range m.actions {
+		copied := *metrics
+		copied.LatencyCount = append([]latencyBucket{}, metrics.LatencyCount...)
+		report.Actions = append(report.Actions, &copied)
+	}
+	report.sort()
+	return report
+}
+
+func (r *apiMetricsReport) sort() {
+	sort.Slice(r.Actions, func(i, j int) bool {
+		if r.Actions[i].Service != r.Actions[j].Service {
+			return r.Actions[i].Service < r.Actions[j].Service
+		}
+		return r.Actions[i].Action < r.Actions[j].Action
+	})
+}
+
+// merge adds the metrics of other report to r
+func (r *apiMetricsReport) merge(other *apiMetricsReport) {
+	actions := make(map[string]*actionMetrics)
+	for _, metrics := range r.Actions {
+		actions[metrics.Service+"/"+metrics.Action] = metrics
+	}
+	for _, metrics := range other.Actions {
+		current, ok := actions[metrics.Service+"/"+metrics.Action]
+		if !ok {
+			current = newActionMetrics(metrics.Service, metrics.Action)
+			actions[metrics.Service+"/"+metrics.Action] = current
+			r.Actions = append(r.Actions, current)
+		}
+		current.Count += metrics.Count
+		current.Errors += metrics.Errors
+		current.Retries += metrics.Retries
+		current.LatencySum += metrics.LatencySum
+		for i := range current.LatencyCount {
+			for _, bucket := range metrics.LatencyCount {
+				if bucket.Le == current.LatencyCount[i].Le {
+					current.LatencyCount[i].Count += bucket.Count
+				}
+			}
+		}
+	}
+	r.sort()
+}
+
+// WriteApiMetrics writes the api call metrics of the provider process to the file of KSYUN_METRICS_FILE,
+// the metrics of the other provider processes in the same terraform run which are in the file are kept
+func WriteApiMetrics() error {
+	path := os.Getenv(metricsFileEnv)
+	if path == "" {
+		return nil
+	}
+	return defaultApiMetrics.write(path)
+}
+
+func (m *apiMetrics) write(path string) error {
+	// the provider processes of the aliases write the file when they exit, so the file is read, merged and
+	// replaced while holding its lock, otherwise the counts of one process are lost by another
+	unlock, err := lockMetricsFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	prometheus := strings.HasSuffix(path, ".prom")
+	report := m.report()
+	if data, err := ioutil.ReadFile(path); err == nil {
+		var previous *apiMetricsReport
+		if prometheus {
+			previous, err = parsePrometheusMetrics(data)
+		} else {
+			previous = &apiMetricsReport{}
+			err = json.Unmarshal(data, previous)
+		}
+		if err == nil && previous.TerraformPid == report.TerraformPid {
+			report.merge(previous)
+		}
+	}
+
+	var data []byte
+	if prometheus {
+		data = report.prometheus()
+	} else if data, err = json.MarshalIndent(report, "", "  "); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path))
+	if err != nil {
+		return fmt.Errorf("error on writing api metrics %q, %s", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("error on writing api metrics %q, %s", path, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("error on writing api metrics %q, %s", path, err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// lockMetricsFile creates the lock file of path exclusively and returns the func removing it,
+// it waits while another provider process holds the lock
+func lockMetricsFile(path string) (unlock func(), err error) {
+	lock := path + ".lock"
+	deadline := time.Now().Add(metricsLockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = f.Close()
+			return func() {
+				_ = os.Remove(lock)
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("error on locking api metrics %q, %s", path, err)
+		}
+		if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) > metricsLockTimeout {
+			_ = os.Remove(lock)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("error on locking api metrics %q, %s is held by another process", path, lock)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// prometheus returns the report in prometheus text exposition format
+func (r *apiMetricsReport) prometheus() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# terraform_pid %d\n", r.TerraformPid)
+	counters := []struct {
+		name  string
+		help  string
+		value func(m *actionMetrics) int64
+	}{
+		{"ksyun_api_calls_total", "Number of ksyun api calls.", func(m *actionMetrics) int64 { return m.Count }},
+		{"ksyun_api_errors_total", "Number of failed ksyun api calls.", func(m *actionMetrics) int64 { return m.Errors }},
+		{"ksyun_api_retries_total", "Number of retried ksyun api calls.", func(m *actionMetrics) int64 { return m.Retries }},
+	}
+	for _, counter := range counters {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s counter\n", counter.name, counter.help, counter.name)
+		for _, m := range r.Actions {
+			fmt.Fprintf(&buf, "%s{service=%q,action=%q} %d\n", counter.name, m.Service, m.Action, counter.value(m))
+		}
+	}
+	name := "ksyun_api_call_duration_seconds"
+	fmt.Fprintf(&buf, "# HELP %s Latency of ksyun api calls.\n# TYPE %s histogram\n", name, name)
+	for _, m := range r.Actions {
+		for _, bucket := range m.LatencyCount {
+			fmt.Fprintf(&buf, "%s_bucket{service=%q,action=%q,le=%q} %d\n", name, m.Service, m.Action, bucket.Le, bucket.Count)
+		}
+		fmt.Fprintf(&buf, "%s_sum{service=%q,action=%q} %s\n", name, m.Service, m.Action, strconv.FormatFloat(m.LatencySum, 'f', -1, 64))
+		fmt.Fprintf(&buf, "%s_count{service=%q,action=%q} %d\n", name, m.Service, m.Action, m.Count)
+	}
+	return buf.Bytes()
+}
+
+var prometheusSampleRegexp = regexp.MustCompile(`^(\w+)\{service="([^"]*)",action="([^"]*)"(?:,le="([^"]*)")?\} (\S+)$`)
+
+// parsePrometheusMetrics reads the report written by prometheus
+func parsePrometheusMetrics(data []byte) (*apiMetricsReport, error) {
+	report := &apiMetricsReport{}
+	actions := make(map[string]*actionMetrics)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "# terraform_pid ") {
+			pid, err := strconv.Atoi(strings.TrimPrefix(line, "# terraform_pid "))
+			if err != nil {
+				return nil, err
+			}
+			report.TerraformPid = pid
+			continue
+		}
+		match := prometheusSampleRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		key := match[2] + "/" + match[3]
+		m, ok := actions[key]
+		if !ok {
+			m = newActionMetrics(match[2], match[3])
+			actions[key] = m
+			report.Actions = append(report.Actions, m)
+		}
+		value, err := strconv.ParseFloat(match[5], 64)
+		if err != nil {
+			return nil, err
+		}
+		switch match[1] {
+		case "ksyun_api_calls_total":
+			m.Count = int64(value)
+		case "ksyun_api_errors_total":
+			m.Errors = int64(value)
+		case "ksyun_api_retries_total":
+			m.Retries = int64(value)
+		case "ksyun_api_call_duration_seconds_sum":
+			m.LatencySum = value
+		case "ksyun_api_call_duration_seconds_bucket":
+			for i := range m.LatencyCount {
+				if m.LatencyCount[i].Le == match[4] {
+					m.LatencyCount[i].Count = int64(value)
+				}
+			}
+		}
+	}
+	return report, scanner.Err()
+}
@@ -0,0 +1,201 @@
+package ksyun
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApiMetricsObserve(t *testing.T) {
+	a := assert.New(t)
+	m := newApiMetrics()
+	m.observe("vpc", "DescribeVpcs", 30*time.Millisecond, false, 0)
+	m.observe("vpc", "DescribeVpcs", 2*time.Second, true, 3)
+	m.observe("kec", "RunInstances", 20*time.Second, false, 0)
+
+	report := m.report()
+	a.Equal(os.Getppid(), report.TerraformPid)
+	a.Len(report.Actions, 2)
+	a.Equal("kec", report.Actions[0].Service)
+	vpcs := report.Actions[1]
+	a.Equal("DescribeVpcs", vpcs.Action)
+	a.Equal(int64(2), vpcs.Count)
+	a.Equal(int64(1), vpcs.Errors)
+	a.Equal(int64(3), vpcs.Retries)
+	a.InDelta(2.03, vpcs.LatencySum, 0.0001)
+	a.Equal(latencyBucket{Le: "0.05", Count: 1}, vpcs.LatencyCount[0])
+	a.Equal(latencyBucket{Le: "1", Count: 1}, vpcs.LatencyCount[4])
+	a.Equal(latencyBucket{Le: "2.5", Count: 2}, vpcs.LatencyCount[5])
+	a.Equal(latencyBucket{Le: "+Inf", Count: 2}, vpcs.LatencyCount[9])
+	a.Equal(latencyBucket{Le: "30", Count: 1}, report.Actions[0].LatencyCount[8])
+}
+
+func TestApiMetricsRetries(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	api.handle("vpc", "DescribeVpcs", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		return nil, newFakeApiError(503, "ServiceUnavailable", "service unavailable")
+	})
+	c := Config{
+		AccessKey:    fakeApiAccessKey,
+		SecretKey:    fakeApiSecretKey,
+		Region:       fakeApiRegion,
+		Endpoints:    map[string]string{"vpc": api.server.URL},
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+	client, err := c.Client()
+	a.Nil(err)
+	client.metrics = newApiMetrics()
+	_, err = client.vpcconn.DescribeVpcs(&map[string]interface{}{})
+	a.NotNil(err)
+
+	report := client.metrics.report()
+	a.Len(report.Actions, 1)
+	a.Equal("vpc", report.Actions[0].Service)
+	a.Equal(int64(1), report.Actions[0].Count)
+	a.Equal(int64(2), report.Actions[0].Retries)
+}
+
+func TestApiMetricsWrite(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "metrics")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+
+	m := newApiMetrics()
+	m.observe("vpc", "DescribeVpcs", 100*time.Millisecond, false, 1)
+
+	for _, name := range []string{"metrics.json", "metrics.prom"} {
+		path := filepath.Join(dir, name)
+		a.Nil(m.write(path))
+		// the second provider process of the same terraform run merges to the file
+		a.Nil(m.write(path))
+		data, err := ioutil.ReadFile(path)
+		a.Nil(err)
+
+		var report *apiMetricsReport
+		if name == "metrics.prom" {
+			a.Contains(string(data), `ksyun_api_calls_total{service="vpc",action="DescribeVpcs"} 2`)
+			a.Contains(string(data), `ksyun_api_call_duration_seconds_bucket{service="vpc",action="DescribeVpcs",le="0.1"} 2`)
+			report, err = parsePrometheusMetrics(data)
+			a.Nil(err)
+		} else {
+			report = &apiMetricsReport{}
+			a.Nil(json.Unmarshal(data, report))
+		}
+		a.Equal(os.Getppid(), report.TerraformPid)
+		a.Len(report.Actions, 1)
+		a.Equal(int64(2), report.Actions[0].Count)
+		a.Equal(int64(2), report.Actions[0].Retries)
+		a.InDelta(0.2, report.Actions[0].LatencySum, 0.0001)
+		a.Equal(int64(2), report.Actions[0].LatencyCount[1].Count)
+		a.Equal(int64(0), report.Actions[0].LatencyCount[0].Count)
+
+		// the file of another terraform run is replaced
+		report.TerraformPid = -1
+		if name == "metrics.prom" {
+			data = report.prometheus()
+		} else {
+			data, _ = json.Marshal(report)
+		}
+		a.Nil(ioutil.WriteFile(path, data, 0644))
+		a.Nil(m.write(path))
+		data, _ = ioutil.ReadFile(path)
+		if name == "metrics.json" {
+			a.Nil(json.Unmarshal(data, report))
+			a.Equal(int64(1), report.Actions[0].Count)
+		} else {
+			report, _ = parsePrometheusMetrics(data)
+			a.Equal(int64(1), report.Actions[0].Count)
+		}
+	}
+}
+
+// metricsWriterEnv makes the test binary run as a provider process writing its api metrics
+const metricsWriterEnv = "KSYUN_METRICS_WRITER"
+
+func TestApiMetricsWriterProcess(t *testing.T) {
+	if os.Getenv(metricsWriterEnv) == "" {
+		t.Skip("only runs as the provider process of TestApiMetricsWriteConcurrently")
+	}
+	defaultApiMetrics.observe("vpc", "DescribeVpcs", time.Millisecond, false, 0)
+	if err := WriteApiMetrics(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApiMetricsWriteConcurrently(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "metrics")
+	a.Nil(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "metrics.json")
+
+	// the provider processes of the aliases in a terraform run write the same file when they exit
+	var cmds []*exec.Cmd
+	for i := 0; i < 20; i++ {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestApiMetricsWriterProcess$")
+		cmd.Env = append(os.Environ(), metricsWriterEnv+"=1", metricsFileEnv+"="+path)
+		a.Nil(cmd.Start())
+		cmds = append(cmds, cmd)
+	}
+	for _, cmd := range cmds {
+		a.Nil(cmd.Wait())
+	}
+	data, err := ioutil.ReadFile(path)
+	a.Nil(err)
+	report := &apiMetricsReport{}
+	a.Nil(json.Unmarshal(data, report))
+	if a.Len(report.Actions, 1) {
+		a.Equal(int64(20), report.Actions[0].Count)
+	}
+	_, err = os.Stat(path + ".lock")
+	a.True(os.IsNotExist(err))
+
+	// the lock left by a killed process is taken over
+	a.Nil(ioutil.WriteFile(path+".lock", nil, 0600))
+	stale := time.Now().Add(-2 * metricsLockTimeout)
+	a.Nil(os.Chtimes(path+".lock", stale, stale))
+	a.Nil(newApiMetrics().write(path))
+}
+
+func TestConfigApiMetrics(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	c := Config{
+		AccessKey: fakeApiAccessKey,
+		SecretKey: fakeApiSecretKey,
+		Region:    fakeApiRegion,
+		Endpoints: map[string]string{"vpc": api.server.URL},
+	}
+	client, err := c.Client()
+	a.Nil(err)
+	client.metrics = newApiMetrics()
+	_, err = client.vpcconn.CreateVpc(&map[string]interface{}{
+		"VpcName":   "tf-vpc",
+		"CidrBlock": "10.0.0.0/16",
+	})
+	a.Nil(err)
+	_, err = client.vpcconn.DeleteVpc(&map[string]interface{}{
+		"VpcId": "vpc-not-found",
+	})
+	a.NotNil(err)
+
+	report := client.metrics.report()
+	a.Len(report.Actions, 2)
+	a.Equal("CreateVpc", report.Actions[0].Action)
+	a.Equal("vpc", report.Actions[0].Service)
+	a.Equal(int64(1), report.Actions[0].Count)
+	a.Equal(int64(0), report.Actions[0].Errors)
+	a.Equal("DeleteVpc", report.Actions[1].Action)
+	a.Equal(int64(1), report.Actions[1].Errors)
+}
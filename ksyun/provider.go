@@ -2,7 +2,9 @@ package ksyun
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"time"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -30,8 +32,7 @@ func Provider() terraform.ResourceProvider {
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     true,
-				DefaultFunc: schema.EnvDefaultFunc("KSYUN_INSECURE", true),
+				DefaultFunc: schema.EnvDefaultFunc("KSYUN_INSECURE", false),
 				Description: descriptions["insecure"],
 			},
 			"domain": {
@@ -52,12 +53,157 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("KSYUN_DOMAIN_IGNORE_SERVICE", false),
 				Description: descriptions["ignore_service"],
 			},
+			"ca_bundle_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KSYUN_CA_BUNDLE_FILE", ""),
+				Description: descriptions["ca_bundle_file"],
+			},
+			"http_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KSYUN_HTTP_PROXY", ""),
+				Description: descriptions["http_proxy"],
+			},
+			"tls_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KSYUN_TLS_SKIP_VERIFY", false),
+				Description: descriptions["tls_skip_verify"],
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        endpointsSchema(),
+				Description: descriptions["endpoints"],
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KSYUN_SHARED_CREDENTIALS_FILE", ""),
+				Description: descriptions["shared_credentials_file"],
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KSYUN_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KSYUN_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_retries"],
+			},
+			"retry_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KSYUN_RETRY_BACKOFF", defaultRetryBackoff.String()),
+				ValidateFunc: validateDuration,
+				Description:  descriptions["retry_backoff"],
+			},
+			"api_rate_limits": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        apiRateLimitsSchema(),
+				Description: descriptions["api_rate_limits"],
+			},
+			"default_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: descriptions["default_tags_tags"],
+						},
+					},
+				},
+				Description: descriptions["default_tags"],
+			},
+			"ignore_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"keys": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: descriptions["ignore_tags_keys"],
+						},
+						"key_prefixes": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: descriptions["ignore_tags_key_prefixes"],
+						},
+					},
+				},
+				Description: descriptions["ignore_tags"],
+			},
+			"log_redact_keys": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: descriptions["log_redact_keys"],
+			},
+			"security_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KSYUN_SECURITY_TOKEN", ""),
+				Description: descriptions["security_token"],
+			},
+			"assume_role": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_krn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: descriptions["assume_role_role_krn"],
+						},
+						"session_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "terraform",
+							Description: descriptions["assume_role_session_name"],
+						},
+						"duration_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3600,
+							ValidateFunc: validation.IntBetween(900, 43200),
+							Description:  descriptions["assume_role_duration_seconds"],
+						},
+						"policy": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.ValidateJsonString,
+							Description:  descriptions["assume_role_policy"],
+						},
+					},
+				},
+				Description: descriptions["assume_role"],
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"ksyun_lines":                         dataSourceKsyunLines(),
 			"ksyun_eips":                          dataSourceKsyunEips(),
 			"ksyun_slbs":                          dataSourceKsyunLbs(),
 			"ksyun_lbs":                           dataSourceKsyunLbs(),
+			"ksyun_lb":                            dataSourceKsyunLb(),
 			"ksyun_listeners":                     dataSourceKsyunListeners(),
 			"ksyun_health_checks":                 dataSourceKsyunHealthChecks(),
 			"ksyun_listener_servers":              dataSourceKsyunLbListenerServers(),
@@ -67,20 +213,30 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_network_interfaces":            dataSourceKsyunNetworkInterfaces(),
 			"ksyun_network_acls":                  dataSourceKsyunNetworkAcls(),
 			"ksyun_vpcs":                          dataSourceKsyunVpcs(),
+			"ksyun_vpc":                           dataSourceKsyunVpc(),
 			"ksyun_subnets":                       dataSourceKsyunSubnets(),
+			"ksyun_subnet":                        dataSourceKsyunSubnet(),
 			"ksyun_subnet_available_addresses":    dataSourceKsyunSubnetAvailableAddresses(),
 			"ksyun_subnet_allocated_ip_addresses": dataSourceKsyunSubnetAllocatedIpAddresses(),
 			"ksyun_security_groups":               dataSourceKsyunSecurityGroups(),
+			"ksyun_security_group":                dataSourceKsyunSecurityGroup(),
 			"ksyun_instances":                     dataSourceKsyunInstances(),
+			"ksyun_instance":                      dataSourceKsyunInstance(),
+			"ksyun_instance_types":                dataSourceKsyunInstanceTypes(),
+			"ksyun_data_guard_groups":             dataSourceKsyunDataGuardGroups(),
+			"ksyun_dedicated_hosts":               dataSourceKsyunDedicatedHosts(),
 			"ksyun_images":                        dataSourceKsyunImages(),
+			"ksyun_image":                         dataSourceKsyunImage(),
 			"ksyun_sqlservers":                    dataSourceKsyunSqlServer(),
 			"ksyun_krds":                          dataSourceKsyunKrds(),
 			"ksyun_krds_security_groups":          dataSourceKsyunKrdsSecurityGroup(),
 			"ksyun_certificates":                  dataSourceKsyunCertificates(),
+			"ksyun_certificate":                   dataSourceKsyunCertificate(),
 			"ksyun_ssh_keys":                      dataSourceKsyunSSHKeys(),
 			"ksyun_redis_instances":               dataSourceRedisInstances(),
 			"ksyun_redis_security_groups":         dataSourceRedisSecurityGroups(),
 			"ksyun_volumes":                       dataSourceKsyunVolumes(),
+			"ksyun_snapshots":                     dataSourceKsyunSnapshots(),
 			"ksyun_mongodbs":                      dataSourceKsyunMongodbs(),
 			"ksyun_lb_host_headers":               dataSourceKsyunListenerHostHeaders(),
 			"ksyun_lb_rules":                      dataSourceKsyunSlbRules(),
@@ -104,6 +260,7 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_bare_metal_images":             dataSourceKsyunBareMetalImages(),
 			"ksyun_bare_metal_raid_attributes":    dataSourceKsyunBareMetalRaidAttributes(),
 			"ksyun_tags":                          dataSourceKsyunTags(),
+			"ksyun_api_call":                      dataSourceKsyunApiCall(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"ksyun_eip":                              resourceKsyunEip(),
@@ -120,6 +277,9 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_security_group":                   resourceKsyunSecurityGroup(),
 			"ksyun_security_group_entry":             resourceKsyunSecurityGroupEntry(),
 			"ksyun_instance":                         resourceKsyunInstance(),
+			"ksyun_data_guard_group":                 resourceKsyunDataGuardGroup(),
+			"ksyun_dedicated_host":                   resourceKsyunDedicatedHost(),
+			"ksyun_image":                            resourceKsyunImage(),
 			"ksyun_sqlserver":                        resourceKsyunSqlServer(),
 			"ksyun_kec_network_interface":            resourceKsyunKecNetworkInterface(),
 			"ksyun_kec_network_interface_attachment": resourceKsyunKecNetworkInterfaceAttachment(),
@@ -140,6 +300,9 @@ func Provider() terraform.ResourceProvider {
 			"ksyun_mongodb_security_rule":            resourceKsyunMongodbSecurityRule(),
 			"ksyun_volume":                           resourceKsyunVolume(),
 			"ksyun_volume_attach":                    resourceKsyunVolumeAttach(),
+			"ksyun_snapshot":                         resourceKsyunSnapshot(),
+			"ksyun_auto_snapshot_policy":             resourceKsyunAutoSnapshotPolicy(),
+			"ksyun_auto_snapshot_policy_attachment":  resourceKsyunAutoSnapshotPolicyAttachment(),
 			"ksyun_lb_rule":                          resourceKsyunSlbRule(),
 			"ksyun_lb_host_header":                   resourceKsyunListenerHostHeader(),
 			"ksyun_lb_backend_server_group":          resourceKsyunBackendServerGroup(),
@@ -174,7 +337,20 @@ func Provider() terraform.ResourceProvider {
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	config := Config{
+	config, err := expandProviderConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	client, err := config.Client()
+	return client, err
+}
+
+func expandProviderConfig(d *schema.ResourceData) (*Config, error) {
+	retryBackoff, err := time.ParseDuration(d.Get("retry_backoff").(string))
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{
 		AccessKey:     d.Get("access_key").(string),
 		SecretKey:     d.Get("secret_key").(string),
 		Region:        d.Get("region").(string),
@@ -182,21 +358,112 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Domain:        d.Get("domain").(string),
 		DryRun:        d.Get("dry_run").(bool),
 		IgnoreService: d.Get("ignore_service").(bool),
+		CaBundleFile:  d.Get("ca_bundle_file").(string),
+		HttpProxy:     d.Get("http_proxy").(string),
+		TlsSkipVerify: d.Get("tls_skip_verify").(bool),
+		Endpoints:     expandProviderEndpoints(d.Get("endpoints")),
+		SecurityToken: d.Get("security_token").(string),
+		AssumeRole:    expandProviderAssumeRole(d.Get("assume_role")),
+
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
+		Profile:               d.Get("profile").(string),
+
+		MaxRetries:   d.Get("max_retries").(int),
+		RetryBackoff: retryBackoff,
+		RateLimits:   expandProviderApiRateLimits(d.Get("api_rate_limits")),
+		DefaultTags:  expandProviderDefaultTags(d.Get("default_tags")),
 	}
-	client, err := config.Client()
-	return client, err
+	config.IgnoreTagKeys, config.IgnoreTagKeyPrefixes = expandProviderIgnoreTags(d.Get("ignore_tags"))
+	for _, v := range d.Get("log_redact_keys").([]interface{}) {
+		config.LogRedactKeys = append(config.LogRedactKeys, v.(string))
+	}
+	return config, nil
 }
 
 var descriptions map[string]string
 
 func init() {
 	descriptions = map[string]string{
-		"access_key":     "ak",
-		"secret_key":     "sk",
-		"region":         "cn-beijing-6",
-		"insecure":       "true",
-		"domain":         "",
-		"dry_run":        "false",
-		"ignore_service": "false",
+		"access_key":      "ak",
+		"secret_key":      "sk",
+		"region":          "cn-beijing-6",
+		"insecure":        "false",
+		"domain":          "",
+		"dry_run":         "false",
+		"ignore_service":  "false",
+		"ca_bundle_file":  "",
+		"http_proxy":      "",
+		"tls_skip_verify": "false",
+		"endpoints":       "",
+		"endpoint":        "",
+		"security_token":  "",
+
+		"shared_credentials_file": "~/.ksyun/config",
+		"profile":                 "default",
+
+		"max_retries":   "3",
+		"retry_backoff": "1s",
+
+		"api_rate_limits": "",
+		"api_rate_limit":  "0",
+
+		"default_tags":      "",
+		"default_tags_tags": "",
+
+		"ignore_tags":              "",
+		"ignore_tags_keys":         "",
+		"ignore_tags_key_prefixes": "",
+
+		"log_redact_keys": "",
+
+		"assume_role":                  "",
+		"assume_role_role_krn":         "",
+		"assume_role_session_name":     "terraform",
+		"assume_role_duration_seconds": "3600",
+		"assume_role_policy":           "",
+	}
+}
+
+func expandProviderDefaultTags(v interface{}) map[string]string {
+	tags := make(map[string]string)
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return tags
+	}
+	m := list[0].(map[string]interface{})
+	if raw, ok := m["tags"].(map[string]interface{}); ok {
+		for k, v := range raw {
+			tags[k] = v.(string)
+		}
+	}
+	return tags
+}
+
+func expandProviderIgnoreTags(v interface{}) (keys []string, keyPrefixes []string) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return keys, keyPrefixes
+	}
+	m := list[0].(map[string]interface{})
+	if s, ok := m["keys"].(*schema.Set); ok {
+		keys = SchemaSetToStringSlice(s)
+	}
+	if s, ok := m["key_prefixes"].(*schema.Set); ok {
+		keyPrefixes = SchemaSetToStringSlice(s)
+	}
+	return keys, keyPrefixes
+}
+
+func expandProviderAssumeRole(v interface{}) *AssumeRoleConfig {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	m := list[0].(map[string]interface{})
+	return &AssumeRoleConfig{
+		RoleKrn:         m["role_krn"].(string),
+		SessionName:     m["session_name"].(string),
+		DurationSeconds: m["duration_seconds"].(int),
+		Policy:          m["policy"].(string),
 	}
 }
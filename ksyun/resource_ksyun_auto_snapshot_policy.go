@@ -0,0 +1,94 @@
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunAutoSnapshotPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunAutoSnapshotPolicyCreate,
+		Read:   resourceKsyunAutoSnapshotPolicyRead,
+		Update: resourceKsyunAutoSnapshotPolicyUpdate,
+		Delete: resourceKsyunAutoSnapshotPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"auto_snapshot_policy_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"auto_snapshot_date": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(1, 7),
+				},
+				Set: schema.HashInt,
+			},
+			"auto_snapshot_time": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 23),
+				},
+				Set: schema.HashInt,
+			},
+			"retention_time": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, 9999),
+			},
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKsyunAutoSnapshotPolicyCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.CreateAutoSnapshotPolicy(d, resourceKsyunAutoSnapshotPolicy())
+	if err != nil {
+		return fmt.Errorf("error on creating auto snapshot policy %q, %s", d.Id(), err)
+	}
+	return resourceKsyunAutoSnapshotPolicyRead(d, meta)
+}
+
+func resourceKsyunAutoSnapshotPolicyRead(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.ReadAndSetAutoSnapshotPolicy(d, resourceKsyunAutoSnapshotPolicy())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error on reading auto snapshot policy %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunAutoSnapshotPolicyUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.ModifyAutoSnapshotPolicy(d, resourceKsyunAutoSnapshotPolicy())
+	if err != nil {
+		return fmt.Errorf("error on updating auto snapshot policy %q, %s", d.Id(), err)
+	}
+	return resourceKsyunAutoSnapshotPolicyRead(d, meta)
+}
+
+func resourceKsyunAutoSnapshotPolicyDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.RemoveAutoSnapshotPolicy(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting auto snapshot policy %q, %s", d.Id(), err)
+	}
+	return err
+}
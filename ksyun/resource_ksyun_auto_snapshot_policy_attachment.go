@@ -0,0 +1,69 @@
+package ksyun
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunAutoSnapshotPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunAutoSnapshotPolicyAttachmentCreate,
+		Read:   resourceKsyunAutoSnapshotPolicyAttachmentRead,
+		Delete: resourceKsyunAutoSnapshotPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: importAutoSnapshotPolicyAttachment,
+		},
+		Schema: map[string]*schema.Schema{
+			"auto_snapshot_policy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"volume_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKsyunAutoSnapshotPolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.CreateAutoSnapshotPolicyAttachment(d)
+	if err != nil {
+		return fmt.Errorf("error on creating auto snapshot policy attachment %q, %s", d.Id(), err)
+	}
+	return resourceKsyunAutoSnapshotPolicyAttachmentRead(d, meta)
+}
+
+func resourceKsyunAutoSnapshotPolicyAttachmentRead(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.ReadAndSetAutoSnapshotPolicyAttachment(d, resourceKsyunAutoSnapshotPolicyAttachment())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error on reading auto snapshot policy attachment %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunAutoSnapshotPolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.RemoveAutoSnapshotPolicyAttachment(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting auto snapshot policy attachment %q, %s", d.Id(), err)
+	}
+	return err
+}
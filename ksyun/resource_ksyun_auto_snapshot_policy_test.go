@@ -0,0 +1,95 @@
+package ksyun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitKsyunAutoSnapshotPolicy_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	volume := api.create("volume", "VolumeId", map[string]interface{}{
+		"VolumeName":       "tf-unit-volume",
+		"VolumeCategory":   "data",
+		"VolumeStatus":     "available",
+		"AvailabilityZone": fakeAvailabilityZones[0],
+		"Size":             20,
+	})
+	volumeId := volume["VolumeId"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_auto_snapshot_policy.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("auto_snapshot_policy", "ksyun_auto_snapshot_policy"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitAutoSnapshotPolicyConfig, "tf-unit-policy", "[1, 3, 5]", 7, volumeId)),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("auto_snapshot_policy", "ksyun_auto_snapshot_policy.foo"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy.foo", "auto_snapshot_policy_name", "tf-unit-policy"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy.foo", "auto_snapshot_date.#", "3"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy.foo", "auto_snapshot_time.#", "2"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy.foo", "retention_time", "7"),
+					resource.TestCheckResourceAttrPair("ksyun_auto_snapshot_policy_attachment.foo", "auto_snapshot_policy_id", "ksyun_auto_snapshot_policy.foo", "id"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy_attachment.foo", "volume_name", "tf-unit-volume"),
+					func(*terraform.State) error {
+						policy := api.order["auto_snapshot_policy"][0]
+						assert.Equal(t, policy, api.resources["volume"][volumeId]["AutoSnapshotPolicyId"])
+						return nil
+					},
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitAutoSnapshotPolicyConfig, "tf-unit-policy-update", "[6, 7]", 30, volumeId)),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("auto_snapshot_policy", "ksyun_auto_snapshot_policy.foo"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy.foo", "auto_snapshot_policy_name", "tf-unit-policy-update"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy.foo", "auto_snapshot_date.#", "2"),
+					resource.TestCheckResourceAttr("ksyun_auto_snapshot_policy.foo", "retention_time", "30"),
+				),
+			},
+			{
+				Config:            api.config(fmt.Sprintf(testUnitAutoSnapshotPolicyConfig, "tf-unit-policy-update", "[6, 7]", 30, volumeId)),
+				ResourceName:      "ksyun_auto_snapshot_policy.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:            api.config(fmt.Sprintf(testUnitAutoSnapshotPolicyConfig, "tf-unit-policy-update", "[6, 7]", 30, volumeId)),
+				ResourceName:      "ksyun_auto_snapshot_policy_attachment.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:  api.config(fmt.Sprintf(testUnitAutoSnapshotPolicyConfig, "tf-unit-policy-update", "[6, 7]", 30, volumeId)),
+				Destroy: true,
+				Check: func(*terraform.State) error {
+					assert.NotContains(t, api.resources["volume"][volumeId], "AutoSnapshotPolicyId")
+					return nil
+				},
+			},
+		},
+	})
+}
+
+const testUnitAutoSnapshotPolicyConfig = `
+resource "ksyun_auto_snapshot_policy" "foo" {
+  auto_snapshot_policy_name = %q
+  auto_snapshot_date        = %s
+  auto_snapshot_time        = [2, 14]
+  retention_time            = %d
+}
+
+resource "ksyun_auto_snapshot_policy_attachment" "foo" {
+  auto_snapshot_policy_id = ksyun_auto_snapshot_policy.foo.id
+  volume_id               = %q
+}
+`
@@ -2,6 +2,7 @@ package ksyun
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"time"
@@ -21,7 +22,7 @@ func resourceKsyunBareMetal() *schema.Resource {
 			Update: schema.DefaultTimeout(3 * time.Hour),
 			Delete: schema.DefaultTimeout(3 * time.Hour),
 		},
-		CustomizeDiff: bareMetalCustomizeDiff,
+		CustomizeDiff: customdiff.All(bareMetalCustomizeDiff, tagsCustomizeDiff),
 		Schema: map[string]*schema.Schema{
 			"availability_zone": {
 				Type:     schema.TypeString,
@@ -286,6 +287,8 @@ func resourceKsyunBareMetal() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
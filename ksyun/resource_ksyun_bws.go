@@ -15,6 +15,7 @@ func resourceKsyunBandWidthShare() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"line_id": {
@@ -48,6 +49,8 @@ func resourceKsyunBandWidthShare() *schema.Resource {
 				Optional: true,
 				Default:  0,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -50,6 +50,10 @@ func resourceKsyunCertificateRead(d *schema.ResourceData, meta interface{}) (err
 	kcmService := KcmService{meta.(*KsyunClient)}
 	err = kcmService.ReadAndSetCertificate(d, resourceKsyunCertificate())
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading certificate %q, %s", d.Id(), err)
 	}
 	return err
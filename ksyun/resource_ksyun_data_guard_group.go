@@ -0,0 +1,80 @@
+package ksyun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunDataGuardGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunDataGuardGroupCreate,
+		Read:   resourceKsyunDataGuardGroupRead,
+		Update: resourceKsyunDataGuardGroupUpdate,
+		Delete: resourceKsyunDataGuardGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"data_guard_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"data_guard_capacity": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"instance_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceKsyunDataGuardGroupCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	kecService := KecService{meta.(*KsyunClient)}
+	err = kecService.CreateDataGuardGroup(d, resourceKsyunDataGuardGroup())
+	if err != nil {
+		return fmt.Errorf("error on creating data guard group %q, %s", d.Id(), err)
+	}
+	return resourceKsyunDataGuardGroupRead(d, meta)
+}
+
+func resourceKsyunDataGuardGroupRead(d *schema.ResourceData, meta interface{}) (err error) {
+	kecService := KecService{meta.(*KsyunClient)}
+	err = kecService.ReadAndSetDataGuardGroup(d, resourceKsyunDataGuardGroup())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error on reading data guard group %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunDataGuardGroupUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	kecService := KecService{meta.(*KsyunClient)}
+	err = kecService.ModifyDataGuardGroup(d, resourceKsyunDataGuardGroup())
+	if err != nil {
+		return fmt.Errorf("error on updating data guard group %q, %s", d.Id(), err)
+	}
+	return resourceKsyunDataGuardGroupRead(d, meta)
+}
+
+func resourceKsyunDataGuardGroupDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	kecService := KecService{meta.(*KsyunClient)}
+	err = kecService.RemoveDataGuardGroup(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting data guard group %q, %s", d.Id(), err)
+	}
+	return err
+}
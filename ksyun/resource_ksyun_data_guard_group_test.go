@@ -0,0 +1,129 @@
+package ksyun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitKsyunDataGuardGroup_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	var instanceId string
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_data_guard_group.a",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("data_guard", "ksyun_data_guard_group"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitDataGuardGroupConfig, "tf-unit-data-guard-a", `data_guard_id = ksyun_data_guard_group.a.id`)),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("data_guard", "ksyun_data_guard_group.a"),
+					resource.TestCheckResourceAttr("ksyun_data_guard_group.a", "data_guard_name", "tf-unit-data-guard-a"),
+					resource.TestCheckResourceAttrPair("ksyun_instance.foo", "data_guard_id", "ksyun_data_guard_group.a", "id"),
+					func(s *terraform.State) error {
+						instanceId = s.RootModule().Resources["ksyun_instance.foo"].Primary.ID
+						assert.Equal(t, s.RootModule().Resources["ksyun_data_guard_group.a"].Primary.ID, api.resources["instance"][instanceId]["DataGuardId"])
+						return nil
+					},
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitDataGuardGroupConfig, "tf-unit-data-guard-a", `data_guard_id = ksyun_data_guard_group.a.id`) + testUnitDataGuardGroupsConfig),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_data_guard_group.a", "instance_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_data_guard_groups.foo", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_data_guard_groups.foo", "data_guard_groups.0.data_guard_name", "tf-unit-data-guard-a"),
+					resource.TestCheckResourceAttr("data.ksyun_data_guard_groups.foo", "data_guard_groups.0.instances.#", "1"),
+					resource.TestCheckResourceAttrPair("data.ksyun_data_guard_groups.foo", "data_guard_groups.0.instances.0.instance_id", "ksyun_instance.foo", "id"),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitDataGuardGroupConfig, "tf-unit-data-guard-update", `data_guard_id = ksyun_data_guard_group.b.id`)),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_data_guard_group.a", "data_guard_name", "tf-unit-data-guard-update"),
+					resource.TestCheckResourceAttrPair("ksyun_instance.foo", "data_guard_id", "ksyun_data_guard_group.b", "id"),
+					func(s *terraform.State) error {
+						assert.Equal(t, instanceId, s.RootModule().Resources["ksyun_instance.foo"].Primary.ID)
+						assert.Equal(t, s.RootModule().Resources["ksyun_data_guard_group.b"].Primary.ID, api.resources["instance"][instanceId]["DataGuardId"])
+						assert.Equal(t, 1, api.called("kec", "RemoveVmFromDataGuard"))
+						return nil
+					},
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitDataGuardGroupConfig, "tf-unit-data-guard-update", "")),
+
+				Check: func(s *terraform.State) error {
+					assert.Equal(t, instanceId, s.RootModule().Resources["ksyun_instance.foo"].Primary.ID)
+					assert.NotContains(t, api.resources["instance"][instanceId], "DataGuardId")
+					return nil
+				},
+			},
+			{
+				Config:            api.config(fmt.Sprintf(testUnitDataGuardGroupConfig, "tf-unit-data-guard-update", "")),
+				ResourceName:      "ksyun_data_guard_group.a",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testUnitDataGuardGroupConfig = `
+resource "ksyun_data_guard_group" "a" {
+  data_guard_name = %q
+}
+
+resource "ksyun_data_guard_group" "b" {
+  data_guard_name = "tf-unit-data-guard-b"
+}
+
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_subnet" "default" {
+  subnet_name       = "ksyun-subnet-tf"
+  cidr_block        = "10.7.0.0/21"
+  subnet_type       = "Normal"
+  vpc_id            = ksyun_vpc.default.id
+  gateway_ip        = "10.7.0.1"
+  dns1              = "198.18.254.41"
+  dns2              = "198.18.254.40"
+  availability_zone = "cn-beijing-6a"
+}
+
+resource "ksyun_security_group" "default" {
+  vpc_id              = ksyun_vpc.default.id
+  security_group_name = "ksyun-security-group"
+}
+
+resource "ksyun_instance" "foo" {
+  image_id          = "IMG-5465174a-6d71-4770-b8e1-917a0dd92466"
+  instance_type     = "S4.1A"
+  subnet_id         = ksyun_subnet.default.id
+  instance_password = "Xuan663222"
+  charge_type       = "Daily"
+  security_group_id = [ksyun_security_group.default.id]
+  instance_name     = "ksyun-kec-tf"
+  force_delete      = true
+  %s
+}
+`
+
+const testUnitDataGuardGroupsConfig = `
+data "ksyun_data_guard_groups" "foo" {
+  ids = [ksyun_data_guard_group.a.id]
+}
+`
@@ -0,0 +1,143 @@
+package ksyun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+func resourceKsyunDedicatedHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunDedicatedHostCreate,
+		Read:   resourceKsyunDedicatedHostRead,
+		Update: resourceKsyunDedicatedHostUpdate,
+		Delete: resourceKsyunDedicatedHostDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"dedicated_host_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"dedicated_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"charge_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"PrePaidByMonth",
+					"Monthly",
+					"PostPaidByDay",
+					"Daily",
+				}, false),
+				DiffSuppressFunc: chargeSchemaDiffSuppressFunc,
+			},
+			"purchase_time": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: purchaseTimeDiffSuppressFunc,
+				ValidateFunc:     validation.IntBetween(0, 36),
+			},
+			"dedicated_cluster_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"project_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cpu": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"memory": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"available_cpu": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"available_memory": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"instance_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKsyunDedicatedHostCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	dedicatedService := DedicatedService{meta.(*KsyunClient)}
+	err = dedicatedService.CreateDedicatedHost(d, resourceKsyunDedicatedHost())
+	if err != nil {
+		return fmt.Errorf("error on creating dedicated host %q, %s", d.Id(), err)
+	}
+	return resourceKsyunDedicatedHostRead(d, meta)
+}
+
+func resourceKsyunDedicatedHostRead(d *schema.ResourceData, meta interface{}) (err error) {
+	dedicatedService := DedicatedService{meta.(*KsyunClient)}
+	err = dedicatedService.ReadAndSetDedicatedHost(d, resourceKsyunDedicatedHost())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error on reading dedicated host %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunDedicatedHostUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	dedicatedService := DedicatedService{meta.(*KsyunClient)}
+	err = dedicatedService.ModifyDedicatedHost(d, resourceKsyunDedicatedHost())
+	if err != nil {
+		return fmt.Errorf("error on updating dedicated host %q, %s", d.Id(), err)
+	}
+	return resourceKsyunDedicatedHostRead(d, meta)
+}
+
+func resourceKsyunDedicatedHostDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	dedicatedService := DedicatedService{meta.(*KsyunClient)}
+	err = dedicatedService.RemoveDedicatedHost(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting dedicated host %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+package ksyun
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitKsyunDedicatedHost_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_dedicated_host.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("dedicated_host", "ksyun_dedicated_host"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitDedicatedHostConfig, "tf-unit-dedicated-host", 0)),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("dedicated_host", "ksyun_dedicated_host.foo"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "dedicated_host_name", "tf-unit-dedicated-host"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "status", "available"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "cpu", "64"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "available_cpu", "64"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "instance_ids.#", "0"),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitDedicatedHostConfig, "tf-unit-dedicated-host-update", 1) + testUnitDedicatedHostInstanceConfig),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "dedicated_host_name", "tf-unit-dedicated-host-update"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "project_id", "1"),
+					resource.TestCheckResourceAttrPair("ksyun_instance.foo", "dedicated_host_id", "ksyun_dedicated_host.foo", "id"),
+					func(s *terraform.State) error {
+						hostId := s.RootModule().Resources["ksyun_dedicated_host.foo"].Primary.ID
+						instanceId := s.RootModule().Resources["ksyun_instance.foo"].Primary.ID
+						assert.Equal(t, hostId, api.resources["instance"][instanceId]["DedicatedHostId"])
+						assert.Equal(t, 1, api.called("kec", "RenameDedicatedHost"))
+						assert.Equal(t, 1, api.called("iam", "UpdateInstanceProjectId"))
+						return nil
+					},
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitDedicatedHostConfig, "tf-unit-dedicated-host-update", 1) + testUnitDedicatedHostInstanceConfig + testUnitDedicatedHostsConfig),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "instance_ids.#", "1"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "available_cpu", "63"),
+					resource.TestCheckResourceAttr("data.ksyun_dedicated_hosts.foo", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ksyun_dedicated_hosts.foo", "dedicated_hosts.0.dedicated_host_name", "tf-unit-dedicated-host-update"),
+					resource.TestCheckResourceAttr("data.ksyun_dedicated_hosts.foo", "dedicated_hosts.0.cpu", "64"),
+					resource.TestCheckResourceAttr("data.ksyun_dedicated_hosts.foo", "dedicated_hosts.0.available_cpu", "63"),
+					resource.TestCheckResourceAttr("data.ksyun_dedicated_hosts.foo", "dedicated_hosts.0.available_memory", "255"),
+					resource.TestCheckResourceAttrPair("data.ksyun_dedicated_hosts.foo", "dedicated_hosts.0.instance_ids.0", "ksyun_instance.foo", "id"),
+					resource.TestCheckResourceAttrPair("data.ksyun_dedicated_hosts.foo", "dedicated_hosts.0.id", "ksyun_dedicated_host.foo", "id"),
+				),
+			},
+			{
+				Config:            api.config(fmt.Sprintf(testUnitDedicatedHostConfig, "tf-unit-dedicated-host-update", 1) + testUnitDedicatedHostInstanceConfig),
+				ResourceName:      "ksyun_dedicated_host.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"purchase_time",
+				},
+			},
+		},
+	})
+}
+
+func TestUnitKsyunDedicatedHost_prepaid(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    api.providers(),
+		CheckDestroy: api.checkDestroy("dedicated_host", "ksyun_dedicated_host"),
+
+		Steps: []resource.TestStep{
+			{
+				Config:      api.config(fmt.Sprintf(testUnitDedicatedHostPrepaidConfig, "")),
+				ExpectError: regexp.MustCompile("purchase_time is required"),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitDedicatedHostPrepaidConfig, "purchase_time = 1")),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("dedicated_host", "ksyun_dedicated_host.foo"),
+					resource.TestCheckResourceAttr("ksyun_dedicated_host.foo", "charge_type", "PrePaidByMonth"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitDedicatedHostConfig = `
+resource "ksyun_dedicated_host" "foo" {
+  dedicated_host_name = %q
+  dedicated_type      = "DC1"
+  availability_zone   = "cn-beijing-6a"
+  charge_type         = "Daily"
+  project_id          = %d
+}
+`
+
+const testUnitDedicatedHostPrepaidConfig = `
+resource "ksyun_dedicated_host" "foo" {
+  dedicated_type    = "DC1"
+  availability_zone = "cn-beijing-6a"
+  charge_type       = "PrePaidByMonth"
+  %s
+}
+`
+
+const testUnitDedicatedHostInstanceConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_subnet" "default" {
+  subnet_name       = "ksyun-subnet-tf"
+  cidr_block        = "10.7.0.0/21"
+  subnet_type       = "Normal"
+  vpc_id            = ksyun_vpc.default.id
+  gateway_ip        = "10.7.0.1"
+  dns1              = "198.18.254.41"
+  dns2              = "198.18.254.40"
+  availability_zone = "cn-beijing-6a"
+}
+
+resource "ksyun_security_group" "default" {
+  vpc_id              = ksyun_vpc.default.id
+  security_group_name = "ksyun-security-group"
+}
+
+resource "ksyun_instance" "foo" {
+  image_id          = "IMG-5465174a-6d71-4770-b8e1-917a0dd92466"
+  instance_type     = "S4.1A"
+  subnet_id         = ksyun_subnet.default.id
+  instance_password = "Xuan663222"
+  charge_type       = "Daily"
+  security_group_id = [ksyun_security_group.default.id]
+  instance_name     = "ksyun-kec-tf"
+  force_delete      = true
+  dedicated_host_id = ksyun_dedicated_host.foo.id
+}
+`
+
+const testUnitDedicatedHostsConfig = `
+data "ksyun_dedicated_hosts" "foo" {
+  ids = [ksyun_dedicated_host.foo.id]
+}
+`
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunEip() *schema.Resource {
@@ -16,6 +15,7 @@ func resourceKsyunEip() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"line_id": {
@@ -61,7 +61,8 @@ func resourceKsyunEip() *schema.Resource {
 				Optional: true,
 				Default:  0,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 
 			"instance_id": {
 				Type:     schema.TypeString,
@@ -121,7 +122,7 @@ func resourceKsyunEipRead(d *schema.ResourceData, meta interface{}) (err error)
 	eipService := EipService{meta.(*KsyunClient)}
 	err = eipService.ReadAndSetAddress(d, resourceKsyunEip())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunEipAssociation() *schema.Resource {
@@ -101,7 +100,7 @@ func resourceKsyunEipAssociationRead(d *schema.ResourceData, meta interface{}) (
 	eipService := EipService{meta.(*KsyunClient)}
 	err = eipService.ReadAndSetAddressAssociate(d, resourceKsyunEipAssociation())
 	if err != nil {
-		if strings.Contains(err.Error(), "not associate in") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
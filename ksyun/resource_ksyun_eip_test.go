@@ -8,8 +8,9 @@ import (
 )
 
 func TestAccKsyunEip_basic(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -29,8 +30,9 @@ func TestAccKsyunEip_basic(t *testing.T) {
 }
 
 func TestAccKsyunEip_update(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -56,6 +58,31 @@ func TestAccKsyunEip_update(t *testing.T) {
 	})
 }
 
+func TestAccKsyunEip_defaultTags(t *testing.T) {
+	var val map[string]interface{}
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "ksyun_eip.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckEipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEipDefaultTagsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEipExists("ksyun_eip.foo", &val),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "tags.env", "prod"),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "tags_all.%", "2"),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "tags_all.env", "prod"),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "tags_all.team", "infra"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckEipExists(n string, val *map[string]interface{}) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -151,3 +178,78 @@ resource "ksyun_eip" "foo" {
   project_id=0
 }
 `
+
+const testAccEipDefaultTagsConfig = `
+provider "ksyun" {
+  default_tags {
+    tags = {
+      env  = "test"
+      team = "infra"
+    }
+  }
+}
+
+data "ksyun_lines" "default" {
+  output_file="output_result1"
+  line_name="BGP"
+}
+# Create an eip
+resource "ksyun_eip" "foo" {
+  line_id ="${data.ksyun_lines.default.lines.0.line_id}"
+  band_width =1
+  charge_type = "PostPaidByDay"
+  purchase_time =1
+  tags = {
+    env = "prod"
+  }
+}
+`
+
+func TestUnitKsyunEip_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_eip.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("eip", "ksyun_eip"),
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitEipConfig, 1)),
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("eip", "ksyun_eip.foo"),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "band_width", "1"),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "line_id", fakeEipLine["LineId"].(string)),
+					resource.TestCheckResourceAttrSet("ksyun_eip.foo", "public_ip"),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitEipConfig, 10)),
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("eip", "ksyun_eip.foo"),
+					resource.TestCheckResourceAttr("ksyun_eip.foo", "band_width", "10"),
+				),
+			},
+			{
+				Config:                  api.config(fmt.Sprintf(testUnitEipConfig, 10)),
+				ResourceName:            "ksyun_eip.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"purchase_time"},
+			},
+		},
+	})
+}
+
+const testUnitEipConfig = `
+data "ksyun_lines" "default" {
+  line_name="BGP"
+}
+resource "ksyun_eip" "foo" {
+  line_id ="${data.ksyun_lines.default.lines.0.line_id}"
+  band_width =%d
+  charge_type = "PostPaidByDay"
+  purchase_time =1
+}
+`
@@ -2,7 +2,6 @@ package ksyun
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceIamAccessKey() *schema.Resource {
@@ -65,7 +64,7 @@ func resourceIamAccessKeyRead(d *schema.ResourceData, m interface{}) (err error)
 
 	resp, err := c.ListAccessKeys(&user)
 	if err != nil {
-		if strings.Contains(err.Error(), "UserNoSuchEntity") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -2,7 +2,6 @@ package ksyun
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceIamGroupMembership() *schema.Resource {
@@ -52,7 +51,7 @@ func resourceIamGroupMembershipRead(d *schema.ResourceData, m interface{}) error
 
 	resp, err := c.ListGroupsForUser(&user)
 	if err != nil {
-		if strings.Contains(err.Error(), "UserNoSuchEntity") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
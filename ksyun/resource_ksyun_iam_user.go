@@ -2,7 +2,6 @@ package ksyun
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceIamUser() *schema.Resource {
@@ -84,7 +83,7 @@ func resourceIamUserRead(d *schema.ResourceData, m interface{}) error {
 
 	resp, err := c.GetUser(&user)
 	if err != nil {
-		if strings.Contains(err.Error(), "UserNoSuchEntity") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
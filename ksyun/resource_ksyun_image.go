@@ -0,0 +1,150 @@
+package ksyun
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunImageCreate,
+		Update: resourceKsyunImageUpdate,
+		Read:   resourceKsyunImageRead,
+		Delete: resourceKsyunImageDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceKsyunImageImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"image_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"data_disk_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"copy_regions": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"copy_image_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"share_account_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"image_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"creation_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"platform": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sys_disk": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"image_source": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKsyunImageCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	imageService := ImageService{meta.(*KsyunClient)}
+	err = imageService.CreateImage(d, resourceKsyunImage())
+	if err != nil {
+		return fmt.Errorf("error on creating image %q, %s", d.Id(), err)
+	}
+	return resourceKsyunImageRead(d, meta)
+}
+
+func resourceKsyunImageRead(d *schema.ResourceData, meta interface{}) (err error) {
+	imageService := ImageService{meta.(*KsyunClient)}
+	err = imageService.ReadAndSetImage(d, resourceKsyunImage())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error on reading image %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunImageUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	imageService := ImageService{meta.(*KsyunClient)}
+	err = imageService.ModifyImage(d)
+	if err != nil {
+		return fmt.Errorf("error on updating image %q, %s", d.Id(), err)
+	}
+	return resourceKsyunImageRead(d, meta)
+}
+
+func resourceKsyunImageDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	imageService := ImageService{meta.(*KsyunClient)}
+	err = imageService.RemoveImage(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting image %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+// resourceKsyunImageImport imports the image by its id, the copies are appended to the id as region=copy_id pairs
+// separated by commas such as img-1:cn-shanghai-2=img-2, they are verified when the image is read
+func resourceKsyunImageImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	copies := make(map[string]interface{})
+	if len(parts) == 2 {
+		for _, pair := range strings.Split(parts[1], ",") {
+			items := strings.SplitN(pair, "=", 2)
+			if len(items) != 2 || items[0] == "" || items[1] == "" {
+				return nil, fmt.Errorf("the copy %q of image must be region=copy_id, such as cn-shanghai-2=IMG-xxx", pair)
+			}
+			copies[items[0]] = items[1]
+		}
+	}
+	d.SetId(parts[0])
+	if err := d.Set("copy_image_ids", copies); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
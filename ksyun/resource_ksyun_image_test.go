@@ -0,0 +1,113 @@
+package ksyun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitKsyunImage_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_image.foo",
+		Providers:     api.providers(),
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			api.checkDestroy("image", "ksyun_image"),
+			func(*terraform.State) error {
+				assert.Empty(t, api.order["image_copy"])
+				return nil
+			},
+		),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitImageConfig, "ksyun-kec-tf", "tf-unit-image", `["100001"]`, `[]`)),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("image", "ksyun_image.foo"),
+					resource.TestCheckResourceAttrPair("ksyun_image.foo", "instance_id", "ksyun_instance.foo", "id"),
+					resource.TestCheckResourceAttr("ksyun_image.foo", "image_name", "tf-unit-image"),
+					resource.TestCheckResourceAttr("ksyun_image.foo", "image_state", "active"),
+					resource.TestCheckResourceAttr("ksyun_image.foo", "share_account_ids.#", "1"),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitImageConfig, "ksyun-kec-tf", "tf-unit-image-update", `["100002"]`, `["cn-shanghai-2"]`)),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("image", "ksyun_image.foo"),
+					resource.TestCheckResourceAttr("ksyun_image.foo", "image_name", "tf-unit-image-update"),
+					resource.TestCheckResourceAttr("ksyun_image.foo", "share_account_ids.#", "1"),
+					resource.TestCheckResourceAttr("ksyun_image.foo", "copy_regions.#", "1"),
+					resource.TestCheckResourceAttr("ksyun_image.foo", "copy_image_ids.%", "1"),
+					func(s *terraform.State) error {
+						if assert.Equal(t, 1, len(api.order["image_copy"])) {
+							return resource.TestCheckResourceAttr("ksyun_image.foo", "copy_image_ids.cn-shanghai-2", api.order["image_copy"][0])(s)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:                  api.config(fmt.Sprintf(testUnitImageConfig, "ksyun-kec-tf", "tf-unit-image-update", `["100002"]`, `["cn-shanghai-2"]`)),
+				ResourceName:            "ksyun_image.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"data_disk_ids"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					image := s.RootModule().Resources["ksyun_image.foo"].Primary
+					return fmt.Sprintf("%s:cn-shanghai-2=%s", image.ID, image.Attributes["copy_image_ids.cn-shanghai-2"]), nil
+				},
+			},
+			{
+				// the copy removed outside of terraform is copied again
+				PreConfig: func() {
+					for _, id := range api.order["image_copy"] {
+						api.remove("image_copy", id)
+					}
+				},
+				Config: api.config(fmt.Sprintf(testUnitImageConfig, "ksyun-kec-tf", "tf-unit-image-update", `["100002"]`, `["cn-shanghai-2"]`)),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_image.foo", "copy_regions.#", "1"),
+					func(s *terraform.State) error {
+						if assert.Equal(t, 1, len(api.order["image_copy"])) {
+							return resource.TestCheckResourceAttr("ksyun_image.foo", "copy_image_ids.cn-shanghai-2", api.order["image_copy"][0])(s)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitImageConfig, "ksyun-kec-tf", "tf-unit-image-update", `["100002"]`, `["cn-guangzhou-1"]`)),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_image.foo", "copy_image_ids.%", "1"),
+					resource.TestCheckNoResourceAttr("ksyun_image.foo", "copy_image_ids.cn-shanghai-2"),
+					func(s *terraform.State) error {
+						if assert.Equal(t, 1, len(api.order["image_copy"])) {
+							assert.Equal(t, "cn-guangzhou-1", api.resources["image_copy"][api.order["image_copy"][0]]["Region"])
+							return resource.TestCheckResourceAttr("ksyun_image.foo", "copy_image_ids.cn-guangzhou-1", api.order["image_copy"][0])(s)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+const testUnitImageConfig = testUnitInstanceConfig + `
+resource "ksyun_image" "foo" {
+  instance_id       = ksyun_instance.foo.id
+  image_name        = "%s"
+  share_account_ids = %s
+  copy_regions      = %s
+}
+`
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 	"time"
 )
 
@@ -17,6 +16,7 @@ func resourceKsyunInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -227,6 +227,11 @@ func resourceKsyunInstance() *schema.Resource {
 			"data_guard_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+			},
+			"dedicated_host_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
 			},
 			"host_name": {
@@ -259,7 +264,8 @@ func resourceKsyunInstance() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 			//"has_init_info": {
 			//	Type:     schema.TypeBool,
 			//	Computed: true,
@@ -321,7 +327,7 @@ func resourceKsyunInstanceRead(d *schema.ResourceData, meta interface{}) (err er
 	kecService := KecService{meta.(*KsyunClient)}
 	err = kecService.readAndSetKecInstance(d, resourceKsyunInstance())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -235,3 +235,67 @@ resource "ksyun_instance" "foo" {
 }
 
 `
+
+func TestUnitKsyunInstance_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_instance.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("instance", "ksyun_instance"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitInstanceConfig, "ksyun-kec-tf")),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("instance", "ksyun_instance.foo"),
+					resource.TestCheckResourceAttr("ksyun_instance.foo", "instance_name", "ksyun-kec-tf"),
+					resource.TestCheckResourceAttr("ksyun_instance.foo", "instance_status", "active"),
+					resource.TestCheckResourceAttrSet("ksyun_instance.foo", "private_ip_address"),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitInstanceConfig, "ksyun-kec-tf-update")),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("instance", "ksyun_instance.foo"),
+					resource.TestCheckResourceAttr("ksyun_instance.foo", "instance_name", "ksyun-kec-tf-update"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitInstanceConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+resource "ksyun_subnet" "default" {
+  subnet_name      = "ksyun-subnet-tf"
+  cidr_block = "10.7.0.0/21"
+  subnet_type = "Normal"
+  vpc_id  = "${ksyun_vpc.default.id}"
+  gateway_ip = "10.7.0.1"
+  dns1 = "198.18.254.41"
+  dns2 = "198.18.254.40"
+  availability_zone = "cn-beijing-6a"
+}
+resource "ksyun_security_group" "default" {
+  vpc_id = "${ksyun_vpc.default.id}"
+  security_group_name="ksyun-security-group"
+}
+resource "ksyun_instance" "foo" {
+  image_id="IMG-5465174a-6d71-4770-b8e1-917a0dd92466"
+  instance_type="S4.1A"
+  subnet_id="${ksyun_subnet.default.id}"
+  instance_password="Xuan663222"
+  charge_type="Daily"
+  security_group_id=["${ksyun_security_group.default.id}"]
+  instance_name="%s"
+  force_delete=true
+}
+`
@@ -2,6 +2,7 @@ package ksyun
 
 import (
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -18,7 +19,7 @@ func resourceKsyunKrds() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
-		CustomizeDiff: krdsInstanceCustomizeDiff(),
+		CustomizeDiff: customdiff.All(krdsInstanceCustomizeDiff(), tagsCustomizeDiff),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(300 * time.Minute),
 			Update: schema.DefaultTimeout(300 * time.Minute),
@@ -183,6 +184,8 @@ func resourceKsyunKrds() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -206,6 +209,10 @@ func resourceKsyunKrdsCreate(d *schema.ResourceData, meta interface{}) (err erro
 func resourceKsyunKrdsRead(d *schema.ResourceData, meta interface{}) (err error) {
 	err = readAndSetKrdsInstance(d, meta, false)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading instance , error is %s", err)
 	}
 	err = readAndSetKrdsInstanceParameters(d, meta)
@@ -70,6 +70,7 @@ func resourceKsyunKrdsRr() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
 			Update: schema.DefaultTimeout(60 * time.Minute),
@@ -105,6 +106,10 @@ func resourceKsyunKrdsRrUpdate(d *schema.ResourceData, meta interface{}) (err er
 func resourceKsyunKrdsRrRead(d *schema.ResourceData, meta interface{}) (err error) {
 	err = readAndSetKrdsInstance(d, meta, true)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading rr instance , error is %s", err)
 	}
 	err = readAndSetKrdsInstanceParameters(d, meta)
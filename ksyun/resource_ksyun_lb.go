@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunLb() *schema.Resource {
@@ -16,6 +15,7 @@ func resourceKsyunLb() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
@@ -81,7 +81,8 @@ func resourceKsyunLb() *schema.Resource {
 				ForceNew: true,
 			},
 
-			"tags": tagsSchema(),
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 
 			"public_ip": {
 				Type:     schema.TypeString,
@@ -130,7 +131,7 @@ func resourceKsyunLbRead(d *schema.ResourceData, meta interface{}) (err error) {
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetLoadBalancer(d, resourceKsyunLb())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunLoadBalancerAcl() *schema.Resource {
@@ -73,7 +72,7 @@ func resourceKsyunLoadBalancerAclRead(d *schema.ResourceData, meta interface{})
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetLoadBalancerAcl(d, resourceKsyunLoadBalancerAcl())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunLoadBalancerAclEntry() *schema.Resource {
@@ -63,7 +62,7 @@ func resourceKsyunLoadBalancerAclEntryRead(d *schema.ResourceData, meta interfac
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetLoadBalancerAclEntry(d, resourceKsyunLoadBalancerAclEntry())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunBackendServerGroup() *schema.Resource {
@@ -93,7 +92,7 @@ func resourceKsyunBackendServerGroupRead(d *schema.ResourceData, meta interface{
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetBackendServerGroup(d, resourceKsyunBackendServerGroup())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunListenerHostHeader() *schema.Resource {
@@ -61,7 +60,7 @@ func resourceKsyunListenerHostHeaderRead(d *schema.ResourceData, meta interface{
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetHostHeader(d, resourceKsyunListenerHostHeader())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunListener() *schema.Resource {
@@ -205,7 +204,7 @@ func resourceKsyunListenerRead(d *schema.ResourceData, meta interface{}) (err er
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetListener(d, resourceKsyunListener())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunListenerAssociateAcl() *schema.Resource {
@@ -42,7 +41,7 @@ func resourceKsyunListenerAssociateAclRead(d *schema.ResourceData, meta interfac
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetLoadBalancerAclAssociate(d, resourceKsyunListenerAssociateAcl())
 	if err != nil {
-		if strings.Contains(err.Error(), "not associate in") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunInstancesWithListener() *schema.Resource {
@@ -95,7 +94,7 @@ func resourceKsyunInstancesWithListenerRead(d *schema.ResourceData, meta interfa
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetRealServer(d, resourceKsyunInstancesWithListener())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunRegisterBackendServer() *schema.Resource {
@@ -88,7 +87,7 @@ func resourceKsyunRegisterBackendServerRead(d *schema.ResourceData, meta interfa
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetBackendServer(d, resourceKsyunRegisterBackendServer())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
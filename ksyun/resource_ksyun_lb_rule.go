@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunSlbRule() *schema.Resource {
@@ -135,7 +134,7 @@ func resourceKsyunSlbRuleRead(d *schema.ResourceData, meta interface{}) (err err
 	slbService := SlbService{meta.(*KsyunClient)}
 	err = slbService.ReadAndSetLbRule(d, resourceKsyunSlbRule())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
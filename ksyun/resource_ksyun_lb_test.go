@@ -8,8 +8,9 @@ import (
 )
 
 func TestAccKsyunLb_basic(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -29,8 +30,9 @@ func TestAccKsyunLb_basic(t *testing.T) {
 }
 
 func TestAccKsyunLb_update(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -153,3 +155,34 @@ resource "ksyun_lb" "foo" {
   private_ip_address = ""
 }
 `
+
+func TestUnitKsyunLb_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_lb.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("slb", "ksyun_lb"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testAccLbUpdateConfig),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("slb", "ksyun_lb.foo"),
+					resource.TestCheckResourceAttr("ksyun_lb.foo", "load_balancer_name", "ksyun-lb-tf-update"),
+					resource.TestCheckResourceAttr("ksyun_lb.foo", "load_balancer_state", "stop"),
+					resource.TestCheckResourceAttrSet("ksyun_lb.foo", "public_ip"),
+				),
+			},
+			{
+				Config:            api.config(testAccLbUpdateConfig),
+				ResourceName:      "ksyun_lb.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
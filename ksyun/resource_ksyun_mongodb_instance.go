@@ -17,6 +17,7 @@ func resourceKsyunMongodbInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(3 * time.Hour),
 			Delete: schema.DefaultTimeout(3 * time.Hour),
@@ -220,6 +221,8 @@ func resourceKsyunMongodbInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -239,7 +242,7 @@ func resourceMongodbInstanceDelete(d *schema.ResourceData, meta interface{}) (er
 			return nil
 		} else {
 			_, err = readMongodbInstance(d, meta, "")
-			if err != nil && canNotFoundMongodbError(err) {
+			if err != nil && isNotFoundError(err) {
 				return nil
 			}
 		}
@@ -1,6 +1,7 @@
 package ksyun
 
 import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"time"
@@ -106,7 +107,7 @@ func resourceKsyunMongodbShardInstance() *schema.Resource {
 			Delete: schema.DefaultTimeout(3 * time.Hour),
 			Update: schema.DefaultTimeout(3 * time.Hour),
 		},
-		CustomizeDiff: mongodbShardInstanceCustomizeDiffFunc(),
+		CustomizeDiff: customdiff.All(mongodbShardInstanceCustomizeDiffFunc(), tagsCustomizeDiff),
 		Schema:        subSchema,
 	}
 }
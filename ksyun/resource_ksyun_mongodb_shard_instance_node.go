@@ -101,7 +101,7 @@ func resourceMongodbShardInstanceNodeDelete(d *schema.ResourceData, meta interfa
 			return nil
 		} else {
 			_, _, err = readMongodbShardInstanceNode(d, meta)
-			if err != nil && canNotFoundMongodbError(err) {
+			if err != nil && isNotFoundError(err) {
 				return nil
 			}
 		}
@@ -112,6 +112,10 @@ func resourceMongodbShardInstanceNodeDelete(d *schema.ResourceData, meta interfa
 func resourceMongodbShardInstanceNodeRead(d *schema.ResourceData, meta interface{}) (err error) {
 	v, extra, err := readMongodbShardInstanceNode(d, meta)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("read shard instance node error %s ", err)
 	}
 	SdkResponseAutoResourceData(d, resourceKsyunMongodbShardInstanceNode(), v, extra)
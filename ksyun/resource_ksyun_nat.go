@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunNat() *schema.Resource {
@@ -16,6 +15,7 @@ func resourceKsyunNat() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"nat_line_id": {
 				Type:     schema.TypeString,
@@ -114,6 +114,8 @@ func resourceKsyunNat() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -131,7 +133,7 @@ func resourceKsyunNatRead(d *schema.ResourceData, meta interface{}) (err error)
 	vpcService := VpcService{meta.(*KsyunClient)}
 	err = vpcService.ReadAndSetNat(d, resourceKsyunNat())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunNetworkAcl() *schema.Resource {
@@ -69,7 +68,7 @@ func resourceKsyunNetworkAclRead(d *schema.ResourceData, meta interface{}) (err
 	vpcService := VpcService{meta.(*KsyunClient)}
 	err = vpcService.ReadAndSetNetworkAcl(d, resourceKsyunNetworkAcl())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
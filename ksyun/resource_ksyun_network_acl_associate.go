@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunNetworkAclAssociate() *schema.Resource {
@@ -42,7 +41,7 @@ func resourceKsyunNetworkAclAssociateRead(d *schema.ResourceData, meta interface
 	vpcService := VpcService{meta.(*KsyunClient)}
 	err = vpcService.ReadAndSetNetworkAclAssociate(d, resourceKsyunNetworkAclAssociate())
 	if err != nil {
-		if strings.Contains(err.Error(), "not associate in") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
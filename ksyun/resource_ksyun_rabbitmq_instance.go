@@ -7,7 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
-	"strings"
 	"time"
 )
 
@@ -20,6 +19,7 @@ func resourceKsyunRabbitmq() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(3 * time.Hour),
 			Delete: schema.DefaultTimeout(3 * time.Hour),
@@ -220,6 +220,8 @@ func resourceKsyunRabbitmq() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 
@@ -235,6 +237,8 @@ func resourceRabbitmqInstanceCreate(d *schema.ResourceData, meta interface{}) er
 	transform := map[string]SdkReqTransform{
 		"force_restart": {Ignore: true},
 		"cidrs":         {Ignore: true},
+		"tags":          {Ignore: true},
+		"tags_all":      {Ignore: true},
 	}
 
 	conn := meta.(*KsyunClient).rabbitmqconn
@@ -279,6 +283,10 @@ func resourceRabbitmqInstanceCreate(d *schema.ResourceData, meta interface{}) er
 	if err != nil {
 		return fmt.Errorf("error on create Instance: %s", err)
 	}
+	err = modifyRabbitmqInstanceTags(d, meta, false)
+	if err != nil {
+		return fmt.Errorf("error on create Instance: %s", err)
+	}
 
 	return resourceRabbitmqInstanceRead(d, meta)
 }
@@ -314,7 +322,7 @@ func resourceRabbitmqInstanceDelete(d *schema.ResourceData, meta interface{}) er
 		logger.Debug(logger.RespFormat, "DescribeRabbitmqInstance", queryReq, resp)
 
 		if err != nil {
-			if strings.Contains(err.Error(), "InstanceNotFound") {
+			if isNotFoundError(err) {
 				return nil
 			} else {
 				return resource.NonRetryableError(err)
@@ -396,6 +404,11 @@ func resourceRabbitmqInstanceUpdate(d *schema.ResourceData, meta interface{}) (e
 	if err != nil {
 		return fmt.Errorf("error on update instance cidrs %q, %s", d.Id(), err)
 	}
+
+	err = modifyRabbitmqInstanceTags(d, meta, true)
+	if err != nil {
+		return fmt.Errorf("error on update instance tags %q, %s", d.Id(), err)
+	}
 	return resourceRabbitmqInstanceRead(d, meta)
 }
 
@@ -414,11 +427,19 @@ func resourceRabbitmqInstanceRead(d *schema.ResourceData, meta interface{}) erro
 
 	item, err = readRabbitmqInstance(d, meta, "")
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 	if _, ok = item["AvailabilityZone"]; ok {
 		delete(item, "AvailabilityZone")
 	}
+	err = mergeTagsData(d, &item, meta.(*KsyunClient), "rabbitmq")
+	if err != nil {
+		return err
+	}
 
 	plugins, err = readRabbitmqInstancePlugins(d, meta, "")
 	if err != nil {
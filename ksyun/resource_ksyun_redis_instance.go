@@ -22,6 +22,7 @@ func resourceRedisInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(3 * time.Hour),
 			Delete: schema.DefaultTimeout(3 * time.Hour),
@@ -252,6 +253,8 @@ func resourceRedisInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -271,6 +274,8 @@ func resourceRedisInstanceCreate(d *schema.ResourceData, meta interface{}) error
 		"reset_all_parameters": {Ignore: true},
 		"parameters":           {Ignore: true},
 		"security_group_id":    {Ignore: true},
+		"tags":                 {Ignore: true},
+		"tags_all":             {Ignore: true},
 		"protocol": {ValueFunc: func(d *schema.ResourceData) (interface{}, bool) {
 			v, ok := d.GetOk("protocol")
 			if ok {
@@ -315,6 +320,10 @@ func resourceRedisInstanceCreate(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return fmt.Errorf("error on create Instance: %s", err)
 	}
+	err = modifyRedisInstanceTags(d, meta, false)
+	if err != nil {
+		return fmt.Errorf("error on create Instance: %s", err)
+	}
 	if len(*createParam) > 0 {
 		err = setResourceRedisInstanceParameter(d, meta, createParam)
 		if err != nil {
@@ -371,7 +380,7 @@ func resourceRedisInstanceDelete(d *schema.ResourceData, meta interface{}) error
 		logger.Debug(logger.RespFormat, action, deleteReq, resp)
 		_, err = describeRedisInstance(d, meta, "")
 		if err != nil {
-			if validateExists(err) {
+			if isNotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
@@ -424,6 +433,11 @@ func resourceRedisInstanceUpdate(d *schema.ResourceData, meta interface{}) (err
 	if err != nil {
 		return fmt.Errorf("error on update instance: %s", err)
 	}
+	// tags
+	err = modifyRedisInstanceTags(d, meta, true)
+	if err != nil {
+		return fmt.Errorf("error on update instance: %s", err)
+	}
 
 	// update parameter
 	if len(*createParam) > 0 {
@@ -445,6 +459,10 @@ func resourceRedisInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	)
 	resp, err = describeRedisInstance(d, meta, "")
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading instance %q, %s", d.Id(), err)
 	}
 	if item, ok = (*resp)["Data"].(map[string]interface{}); !ok {
@@ -463,6 +481,10 @@ func resourceRedisInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	for k, v := range add {
 		item[k] = v
 	}
+	err = mergeTagsData(d, &item, meta.(*KsyunClient), "kcs")
+	if err != nil {
+		return fmt.Errorf("error on reading instance %q, %s", d.Id(), err)
+	}
 	extra := make(map[string]SdkResponseMapping)
 	extra["protocol"] = SdkResponseMapping{
 		Field: "protocol",
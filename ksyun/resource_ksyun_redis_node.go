@@ -139,7 +139,7 @@ func resourceRedisInstanceNodeDelete(d *schema.ResourceData, meta interface{}) e
 		}
 		_, err = readRedisInstanceNode(d, meta)
 		if err != nil {
-			if validateRedisNodeExists(err) {
+			if isNotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
@@ -149,10 +149,6 @@ func resourceRedisInstanceNodeDelete(d *schema.ResourceData, meta interface{}) e
 
 }
 
-func validateRedisNodeExists(err error) bool {
-	return strings.Contains(strings.ToLower(err.Error()), "not exist")
-}
-
 func readRedisInstanceNodeCluster(d *schema.ResourceData, meta interface{}) (*map[string]interface{}, error) {
 	var (
 		resp *map[string]interface{}
@@ -172,7 +168,7 @@ func readRedisInstanceNodeCluster(d *schema.ResourceData, meta interface{}) (*ma
 		},
 	}
 	resp, err = integrationAzConf.integrationRedisAz()
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		return resp, fmt.Errorf("error on reading instance node Cluster %q, %s", d.Id(), err)
 	}
 	return resp, err
@@ -225,14 +221,17 @@ func readRedisInstanceNode(d *schema.ResourceData, meta interface{}) (*map[strin
 	logger.Debug(logger.ReqFormat, action, readReq)
 	resp, err = integrationAzConf.integrationRedisAz()
 	if err != nil {
+		if isNotFoundError(err) {
+			return resp, err
+		}
 		return resp, fmt.Errorf("error on reading instance node %q, %s", d.Id(), err)
 	}
 	if item, ok = (*resp)["Data"]; !ok {
-		return resp, fmt.Errorf("error on reading instance node %s not exist", d.Id())
+		return resp, newNotFoundError(fmt.Sprintf("error on reading instance node %s not exist", d.Id()))
 	}
 	items, ok := item.([]interface{})
 	if !ok || len(items) == 0 {
-		return resp, fmt.Errorf("error on reading instance node %s not exist", d.Id())
+		return resp, newNotFoundError(fmt.Sprintf("error on reading instance node %s not exist", d.Id()))
 	}
 	for _, v := range items {
 		vMap := v.(map[string]interface{})
@@ -240,7 +239,7 @@ func readRedisInstanceNode(d *schema.ResourceData, meta interface{}) (*map[strin
 			return &vMap, err
 		}
 	}
-	return resp, fmt.Errorf("error on reading instance node %s not exist", d.Id())
+	return resp, newNotFoundError(fmt.Sprintf("error on reading instance node %s not exist", d.Id()))
 }
 
 func resourceRedisInstanceNodeRead(d *schema.ResourceData, meta interface{}) error {
@@ -249,12 +248,14 @@ func resourceRedisInstanceNodeRead(d *schema.ResourceData, meta interface{}) err
 		err  error
 	)
 	_, err = readRedisInstanceNodeCluster(d, meta)
-	if err != nil {
-		return err
+	if err == nil {
+		resp, err = readRedisInstanceNode(d, meta)
 	}
-
-	resp, err = readRedisInstanceNode(d, meta)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 	SdkResponseAutoResourceData(d, resourceRedisInstanceNode(), *resp, nil)
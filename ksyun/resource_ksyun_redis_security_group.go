@@ -133,7 +133,7 @@ func resourceRedisSecurityGroupDelete(d *schema.ResourceData, meta interface{})
 		}
 		_, err = readRedisSecurityGroup(d, meta, "")
 		if err != nil {
-			if validateRedisSgExists(err) {
+			if isNotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
@@ -200,6 +200,10 @@ func resourceRedisSecurityGroupRead(d *schema.ResourceData, meta interface{}) er
 	)
 	resp, err = readRedisSecurityGroup(d, meta, "")
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 	extra := make(map[string]SdkResponseMapping)
@@ -154,6 +154,10 @@ func resourceRedisSecurityGroupRuleRead(d *schema.ResourceData, meta interface{}
 	)
 	resp, err = readRedisSecurityGroup(d, meta, d.Get("security_group_id").(string))
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 	data := (*resp)["Data"].(map[string]interface{})
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 )
 
 func resourceKsyunRoute() *schema.Resource {
@@ -106,7 +105,7 @@ func resourceKsyunRouteRead(d *schema.ResourceData, meta interface{}) (err error
 	vpcService := VpcService{meta.(*KsyunClient)}
 	err = vpcService.ReadAndSetRoute(d, resourceKsyunRoute())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -291,6 +291,10 @@ func resourceKsyunScalingConfigurationRead(d *schema.ResourceData, meta interfac
 	logger.Debug(logger.ReqFormat, action, readScalingConfiguration)
 	resp, err := conn.DescribeScalingConfiguration(&readScalingConfiguration)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading ScalingConfiguration %q, %s", d.Id(), err)
 	}
 	if resp != nil {
@@ -319,7 +323,7 @@ func resourceKsyunScalingConfigurationDelete(d *schema.ResourceData, meta interf
 		logger.Debug(logger.AllFormat, action, deleteScalingConfiguration, resp, err1)
 		if err1 == nil {
 			return nil
-		} else if notFoundError(err1) {
+		} else if isNotFoundError(err1) {
 			return nil
 		} else {
 			return OtherErrorProcess(&otherErrorRetry, fmt.Errorf("error on  deleting ScalingConfiguration %q, %s", d.Id(), err1))
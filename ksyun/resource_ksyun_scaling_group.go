@@ -366,6 +366,10 @@ func resourceKsyunScalingGroupRead(d *schema.ResourceData, meta interface{}) err
 	logger.Debug(logger.ReqFormat, action, req)
 	resp, err := conn.DescribeScalingGroup(&req)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading ScalingGroup %q, %s", d.Id(), err)
 	}
 	if resp != nil {
@@ -403,7 +407,7 @@ func resourceKsyunScalingGroupDelete(d *schema.ResourceData, meta interface{}) e
 		_, err1 := conn.DeleteScalingGroup(&req)
 		if err1 == nil {
 			return nil
-		} else if notFoundErrorNew(err1) {
+		} else if isNotFoundError(err1) {
 			return nil
 		} else {
 			return resource.RetryableError(fmt.Errorf("error on  deleting ScalingGroup %q, %s", d.Id(), err1))
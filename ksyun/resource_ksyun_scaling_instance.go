@@ -165,6 +165,10 @@ func resourceKsyunScalingInstanceRead(d *schema.ResourceData, meta interface{})
 	logger.Debug(logger.ReqFormat, action, req)
 	resp, err := conn.DescribeScalingInstance(&req)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading ScalingInstance %q, %s", d.Id(), err)
 	}
 	if resp != nil {
@@ -193,7 +197,7 @@ func resourceKsyunScalingInstanceDelete(d *schema.ResourceData, meta interface{}
 		logger.Debug(logger.AllFormat, action, req, resp, err1)
 		if err1 == nil {
 			return nil
-		} else if notFoundError(err1) {
+		} else if isNotFoundError(err1) {
 			return nil
 		} else {
 			return OtherErrorProcess(&otherErrorRetry, fmt.Errorf("error on  deleting ScalingInstance %q, %s", d.Id(), err1))
@@ -132,6 +132,10 @@ func resourceKsyunScalingNotificationRead(d *schema.ResourceData, meta interface
 	logger.Debug(logger.ReqFormat, action, req)
 	resp, err := conn.DescribeScalingNotification(&req)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading ScalingNotification %q, %s", d.Id(), err)
 	}
 	if resp != nil {
@@ -160,7 +164,7 @@ func resourceKsyunScalingNotificationDelete(d *schema.ResourceData, meta interfa
 		logger.Debug(logger.AllFormat, action, req, resp, err1)
 		if err1 == nil {
 			return nil
-		} else if notFoundError(err1) {
+		} else if isNotFoundError(err1) {
 			return nil
 		} else {
 			return OtherErrorProcess(&otherErrorRetry, fmt.Errorf("error on  deleting ScalingNotification %q, %s", d.Id(), err1))
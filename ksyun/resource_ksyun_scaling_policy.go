@@ -217,6 +217,10 @@ func resourceKsyunScalingPolicyRead(d *schema.ResourceData, meta interface{}) er
 	logger.Debug(logger.ReqFormat, action, req)
 	resp, err := conn.DescribeScalingPolicy(&req)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading ScalingPolicy %q, %s", d.Id(), err)
 	}
 	if resp != nil {
@@ -246,7 +250,7 @@ func resourceKsyunScalingPolicyDelete(d *schema.ResourceData, meta interface{})
 		logger.Debug(logger.AllFormat, action, req, resp, err1)
 		if err1 == nil {
 			return nil
-		} else if notFoundError(err1) {
+		} else if isNotFoundError(err1) {
 			return nil
 		} else {
 			return OtherErrorProcess(&otherErrorRetry, fmt.Errorf("error on  deleting ScalingPolicy %q, %s", d.Id(), err1))
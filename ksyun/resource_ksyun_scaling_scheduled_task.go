@@ -188,6 +188,10 @@ func resourceKsyunScalingScheduledTaskRead(d *schema.ResourceData, meta interfac
 	logger.Debug(logger.ReqFormat, action, req)
 	resp, err := conn.DescribeScheduledTask(&req)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading ScalingScheduledTask %q, %s", d.Id(), err)
 	}
 	if resp != nil {
@@ -216,7 +220,7 @@ func resourceKsyunScalingScheduledTaskDelete(d *schema.ResourceData, meta interf
 		logger.Debug(logger.AllFormat, action, req, resp, err1)
 		if err1 == nil {
 			return nil
-		} else if notFoundError(err1) {
+		} else if isNotFoundError(err1) {
 			return nil
 		} else {
 			return OtherErrorProcess(&otherErrorRetry, fmt.Errorf("error on  deleting ScalingScheduledTask %q, %s", d.Id(), err1))
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunSecurityGroup() *schema.Resource {
@@ -23,6 +22,7 @@ func resourceKsyunSecurityGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"vpc_id": {
 				Type:     schema.TypeString,
@@ -52,6 +52,8 @@ func resourceKsyunSecurityGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -69,7 +71,7 @@ func resourceKsyunSecurityGroupRead(d *schema.ResourceData, meta interface{}) (e
 	vpcService := VpcService{meta.(*KsyunClient)}
 	err = vpcService.ReadAndSetSecurityGroup(d, resourceKsyunSecurityGroup())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -196,3 +196,55 @@ resource "ksyun_security_group_entry" "foo" {
   port_range_to=0
 }
 `
+
+func TestUnitKsyunSecurityGroupEntry_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_security_group_entry.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("security_group", "ksyun_security_group"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitSecurityGroupEntryConfig, "ssh")),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("ksyun_security_group_entry.foo", "security_group_entry_id"),
+					resource.TestCheckResourceAttr("ksyun_security_group_entry.foo", "description", "ssh"),
+					resource.TestCheckResourceAttr("ksyun_security_group_entry.foo", "port_range_from", "22"),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitSecurityGroupEntryConfig, "ssh-update")),
+
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ksyun_security_group_entry.foo", "description", "ssh-update"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitSecurityGroupEntryConfig = `
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+
+resource "ksyun_security_group" "default" {
+  vpc_id = "${ksyun_vpc.default.id}"
+  security_group_name="ksyun-security-group"
+}
+resource "ksyun_security_group_entry" "foo" {
+  description = "%s"
+  security_group_id="${ksyun_security_group.default.id}"
+  cidr_block="10.0.1.1/32"
+  direction="in"
+  protocol="tcp"
+  port_range_from=22
+  port_range_to=22
+}
+`
@@ -8,8 +8,9 @@ import (
 )
 
 func TestAccKsyunSecurityGroup_basic(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -29,8 +30,9 @@ func TestAccKsyunSecurityGroup_basic(t *testing.T) {
 }
 
 func TestAccKsyunSecurityGroup_update(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -144,3 +146,40 @@ resource "ksyun_security_group" "foo" {
   security_group_name="ksyun-security-group-update"
 }
 `
+
+func TestUnitKsyunSecurityGroup_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_security_group.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("security_group", "ksyun_security_group"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testAccSecurityGroupConfig),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("security_group", "ksyun_security_group.foo"),
+					resource.TestCheckResourceAttr("ksyun_security_group.foo", "security_group_name", "ksyun-security-group"),
+				),
+			},
+			{
+				Config: api.config(testAccSecurityGroupUpdateConfig),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("security_group", "ksyun_security_group.foo"),
+					resource.TestCheckResourceAttr("ksyun_security_group.foo", "security_group_name", "ksyun-security-group-update"),
+				),
+			},
+			{
+				Config:            api.config(testAccSecurityGroupUpdateConfig),
+				ResourceName:      "ksyun_security_group.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,108 @@
+package ksyun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceKsyunSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKsyunSnapshotCreate,
+		Read:   resourceKsyunSnapshotRead,
+		Update: resourceKsyunSnapshotUpdate,
+		Delete: resourceKsyunSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"snapshot_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"snapshot_desc": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"snapshot_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"snapshot_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"volume_category": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"progress": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKsyunSnapshotCreate(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.CreateSnapshot(d, resourceKsyunSnapshot())
+	if err != nil {
+		return fmt.Errorf("error on creating snapshot %q, %s", d.Id(), err)
+	}
+	return resourceKsyunSnapshotRead(d, meta)
+}
+
+func resourceKsyunSnapshotRead(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.ReadAndSetSnapshot(d, resourceKsyunSnapshot())
+	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error on reading snapshot %q, %s", d.Id(), err)
+	}
+	return err
+}
+
+func resourceKsyunSnapshotUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.ModifySnapshot(d, resourceKsyunSnapshot())
+	if err != nil {
+		return fmt.Errorf("error on updating snapshot %q, %s", d.Id(), err)
+	}
+	return resourceKsyunSnapshotRead(d, meta)
+}
+
+func resourceKsyunSnapshotDelete(d *schema.ResourceData, meta interface{}) (err error) {
+	ebsService := EbsService{meta.(*KsyunClient)}
+	err = ebsService.RemoveSnapshot(d)
+	if err != nil {
+		return fmt.Errorf("error on deleting snapshot %q, %s", d.Id(), err)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package ksyun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestUnitKsyunSnapshot_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	volume := api.create("volume", "VolumeId", map[string]interface{}{
+		"VolumeName":       "tf-unit-volume",
+		"VolumeCategory":   "data",
+		"VolumeStatus":     "available",
+		"VolumeType":       "SSD3.0",
+		"AvailabilityZone": fakeAvailabilityZones[0],
+		"Size":             20,
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_snapshot.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("snapshot", "ksyun_snapshot"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitSnapshotConfig, volume["VolumeId"], "tf-unit-snapshot")),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("snapshot", "ksyun_snapshot.foo"),
+					resource.TestCheckResourceAttr("ksyun_snapshot.foo", "snapshot_name", "tf-unit-snapshot"),
+					resource.TestCheckResourceAttr("ksyun_snapshot.foo", "snapshot_status", "available"),
+					resource.TestCheckResourceAttr("ksyun_snapshot.foo", "size", "20"),
+					resource.TestCheckResourceAttr("ksyun_snapshot.foo", "availability_zone", fakeAvailabilityZones[0]),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitSnapshotConfig, volume["VolumeId"], "tf-unit-snapshot-update") + testUnitSnapshotsConfig),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("snapshot", "ksyun_snapshot.foo"),
+					resource.TestCheckResourceAttr("ksyun_snapshot.foo", "snapshot_name", "tf-unit-snapshot-update"),
+					resource.TestCheckResourceAttr("data.ksyun_snapshots.foo", "total_count", "1"),
+					resource.TestCheckResourceAttrPair("data.ksyun_snapshots.foo", "snapshots.0.id", "ksyun_snapshot.foo", "id"),
+					resource.TestCheckResourceAttr("data.ksyun_snapshots.foo", "snapshots.0.snapshot_name", "tf-unit-snapshot-update"),
+				),
+			},
+			{
+				Config:            api.config(fmt.Sprintf(testUnitSnapshotConfig, volume["VolumeId"], "tf-unit-snapshot-update")),
+				ResourceName:      "ksyun_snapshot.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testUnitSnapshotConfig = `
+resource "ksyun_snapshot" "foo" {
+  volume_id     = %q
+  snapshot_name = %q
+  snapshot_desc = "tf unit snapshot"
+}
+`
+
+const testUnitSnapshotsConfig = `
+data "ksyun_snapshots" "foo" {
+  volume_id = ksyun_snapshot.foo.volume_id
+  ids       = [ksyun_snapshot.foo.id]
+}
+`
@@ -29,6 +29,7 @@ func resourceKsyunSqlServer() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(50 * time.Minute),
 			Update: schema.DefaultTimeout(30 * time.Minute),
@@ -138,6 +139,8 @@ func resourceKsyunSqlServer() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -194,10 +197,14 @@ func resourceKsyunSqlServerCreate(d *schema.ResourceData, meta interface{}) erro
 		MinTimeout: 10 * time.Second,
 		Refresh:    sqlserverInstanceStateRefreshForCreate(conn, d.Id(), []string{tCreatingStatus}),
 	}
-	_, err = stateConf.WaitForState()
+	_, err = meta.(*KsyunClient).waitForState(stateConf)
 	if err != nil {
 		return err
 	}
+	err = modifySqlServerTags(d, meta, false)
+	if err != nil {
+		return fmt.Errorf("error on creating Instance(sqlserver): %s", err)
+	}
 
 	return resourceKsyunSqlServerRead(d, meta)
 }
@@ -230,6 +237,10 @@ func resourceKsyunSqlServerRead(d *schema.ResourceData, meta interface{}) error
 	resp, err := conn.DescribeDBInstances(&req)
 	logger.Debug(logger.AllFormat, action, req, *resp, err)
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("error on reading Instance(sqlserver) %q, %s", d.Id(), err)
 	}
 
@@ -261,6 +272,14 @@ func resourceKsyunSqlServerRead(d *schema.ResourceData, meta interface{}) error
 
 	logger.DebugInfo(" converted ---- %+v ", sqlserverMap)
 	_ = SetDByFkResp(d, sqlserverMap[0], getSqlserverInTheCar)
+
+	tags := make(map[string]interface{})
+	err = mergeTagsData(d, &tags, meta.(*KsyunClient), "sqlserver")
+	if err != nil {
+		return fmt.Errorf("error on reading Instance(sqlserver) tags %q, %s", d.Id(), err)
+	}
+	_ = d.Set("tags", tags["Tags"])
+	_ = d.Set("tags_all", tags["TagsAll"])
 	return nil
 }
 
@@ -292,9 +311,22 @@ func resourceKsyunSqlServerUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 	d.Partial(false)
+	err := modifySqlServerTags(d, meta, true)
+	if err != nil {
+		return fmt.Errorf("error on updating instance tags %q, %s", d.Id(), err)
+	}
 	return nil
 }
 
+func modifySqlServerTags(d *schema.ResourceData, meta interface{}, isUpdate bool) error {
+	tagService := TagService{meta.(*KsyunClient)}
+	call, err := tagService.ReplaceResourcesTagsWithResourceFunc(d, resourceKsyunSqlServer(), "sqlserver", isUpdate)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCall([]ksyunApiCallFunc{call}, d, meta)
+}
+
 func resourceKsyunSqlServerDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*KsyunClient).sqlserverconn
 	deleteReq := make(map[string]interface{})
@@ -308,7 +340,7 @@ func resourceKsyunSqlServerDelete(d *schema.ResourceData, meta interface{}) erro
 		logger.Debug(logger.AllFormat, discribeAction, readReq, *desResp, desErr)
 
 		if desErr != nil {
-			if notFoundError(desErr) {
+			if isNotFoundError(desErr) {
 				return nil
 			} else {
 				return resource.NonRetryableError(desErr)
@@ -325,7 +357,7 @@ func resourceKsyunSqlServerDelete(d *schema.ResourceData, meta interface{}) erro
 			logger.Debug(logger.ReqFormat, deleteAction, deleteReq)
 			deleteResp, deleteErr := conn.DeleteDBInstance(&deleteReq)
 			logger.Debug(logger.AllFormat, deleteAction, deleteReq, *deleteResp, deleteErr)
-			if deleteErr == nil || notFoundError(deleteErr) {
+			if deleteErr == nil || isNotFoundError(deleteErr) {
 				return nil
 			}
 			if deleteErr != nil {
@@ -337,7 +369,7 @@ func resourceKsyunSqlServerDelete(d *schema.ResourceData, meta interface{}) erro
 			logger.Debug(logger.AllFormat, discribeAction, readReq, *postDesResp, postDesErr)
 
 			if desErr != nil {
-				if notFoundError(desErr) {
+				if isNotFoundError(desErr) {
 					return nil
 				} else {
 					return resource.NonRetryableError(fmt.Errorf("error on  reading kec when delete %q, %s", d.Id(), desErr))
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunSSHKey() *schema.Resource {
@@ -55,7 +54,7 @@ func resourceKsyunSSHKeyRead(d *schema.ResourceData, meta interface{}) (err erro
 	sksService := SksService{meta.(*KsyunClient)}
 	err = sksService.ReadAndSetKey(d, resourceKsyunSSHKey())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
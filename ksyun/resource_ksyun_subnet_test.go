@@ -182,3 +182,63 @@ resource "ksyun_subnet" "foo" {
   availability_zone = "${data.ksyun_availability_zones.default.availability_zones.0.availability_zone_name}"
 }
 `
+
+func TestUnitKsyunSubnet_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_subnet.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("subnet", "ksyun_subnet"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(fmt.Sprintf(testUnitSubnetConfig, "ksyun-subnet-tf")),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("subnet", "ksyun_subnet.foo"),
+					resource.TestCheckResourceAttr("ksyun_subnet.foo", "subnet_name", "ksyun-subnet-tf"),
+					resource.TestCheckResourceAttr("ksyun_subnet.foo", "availability_zone", fakeAvailabilityZones[0]),
+				),
+			},
+			{
+				Config: api.config(fmt.Sprintf(testUnitSubnetConfig, "ksyun-subnet-tf-update")),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("subnet", "ksyun_subnet.foo"),
+					resource.TestCheckResourceAttr("ksyun_subnet.foo", "subnet_name", "ksyun-subnet-tf-update"),
+				),
+			},
+			{
+				Config:            api.config(fmt.Sprintf(testUnitSubnetConfig, "ksyun-subnet-tf-update")),
+				ResourceName:      "ksyun_subnet.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testUnitSubnetConfig = `
+data "ksyun_availability_zones" "default" {
+  output_file=""
+}
+resource "ksyun_vpc" "default" {
+  vpc_name   = "ksyun-vpc-tf"
+  cidr_block = "10.7.0.0/21"
+}
+resource "ksyun_subnet" "foo" {
+  subnet_name      = "%s"
+  cidr_block = "10.7.0.0/21"
+  subnet_type = "Normal"
+  dhcp_ip_from = "10.7.0.2"
+  dhcp_ip_to = "10.7.0.253"
+  vpc_id  = "${ksyun_vpc.default.id}"
+  gateway_ip = "10.7.0.1"
+  dns1 = "198.18.254.41"
+  dns2 = "198.18.254.40"
+  availability_zone = "${data.ksyun_availability_zones.default.availability_zones.0.availability_zone_name}"
+}
+`
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunTag() *schema.Resource {
@@ -77,7 +76,7 @@ func resourceKsyunTagRead(d *schema.ResourceData, meta interface{}) (err error)
 	tagService := TagV1Service{meta.(*KsyunClient)}
 	err = tagService.ReadAndSetTag(d, resourceKsyunTag())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -18,6 +17,7 @@ func resourceKsyunVolume() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Update: schema.DefaultTimeout(5 * time.Minute),
@@ -107,6 +107,8 @@ func resourceKsyunVolume() *schema.Resource {
 				ForceNew:         true,
 				DiffSuppressFunc: kecDiskSnapshotIdDiffSuppress,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -125,7 +127,7 @@ func resourceKsyunVolumeRead(d *schema.ResourceData, meta interface{}) (err erro
 	ebsService := EbsService{meta.(*KsyunClient)}
 	err = ebsService.ReadAndSetVolume(d, resourceKsyunVolume())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -2,7 +2,6 @@ package ksyun
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -89,7 +88,7 @@ func resourceKsyunVolumeAttachRead(d *schema.ResourceData, meta interface{}) (er
 	ebsService := EbsService{meta.(*KsyunClient)}
 	err = ebsService.ReadAndSetVolumeAttach(d, resourceKsyunVolumeAttach())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -3,7 +3,6 @@ package ksyun
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"strings"
 )
 
 func resourceKsyunVpc() *schema.Resource {
@@ -15,6 +14,7 @@ func resourceKsyunVpc() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: tagsCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"vpc_name": {
 				Type:     schema.TypeString,
@@ -38,6 +38,8 @@ func resourceKsyunVpc() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsAllSchema(),
 		},
 	}
 }
@@ -55,7 +57,7 @@ func resourceKsyunVpcRead(d *schema.ResourceData, meta interface{}) (err error)
 	vpcService := VpcService{meta.(*KsyunClient)}
 	err = vpcService.ReadAndSetVpc(d, resourceKsyunVpc())
 	if err != nil {
-		if strings.Contains(err.Error(), "not exist") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
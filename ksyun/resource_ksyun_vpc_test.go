@@ -8,9 +8,10 @@ import (
 )
 
 func TestAccKsyunVPC_basic(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
 
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -35,9 +36,10 @@ func TestAccKsyunVPC_basic(t *testing.T) {
 }
 
 func TestAccKsyunVPC_update(t *testing.T) {
+	defer testAccCassettes.start(t)()
 	var val map[string]interface{}
 
-	resource.ParallelTest(t, resource.TestCase{
+	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -70,6 +72,43 @@ func TestAccKsyunVPC_update(t *testing.T) {
 	})
 }
 
+func TestAccKsyunVPC_tags(t *testing.T) {
+	defer testAccCassettes.start(t)()
+	var val map[string]interface{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "ksyun_vpc.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVPCDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVPCConfigTags,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCExists("ksyun_vpc.foo", &val),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.env", "test"),
+				),
+			},
+			{
+				Config: testAccVPCConfigTagsUpdate,
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVPCExists("ksyun_vpc.foo", &val),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.%", "2"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.env", "prod"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.team", "infra"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVPCExists(n string, val *map[string]interface{}) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -155,3 +194,64 @@ resource "ksyun_vpc" "foo" {
     cidr_block      = "192.168.0.0/16"
 }
 `
+
+const testAccVPCConfigTags = `
+resource "ksyun_vpc" "foo" {
+	vpc_name        = "tf-acc-vpc"
+	cidr_block = "192.168.0.0/16"
+	tags = {
+		env = "test"
+	}
+}
+`
+
+const testAccVPCConfigTagsUpdate = `
+resource "ksyun_vpc" "foo" {
+	vpc_name        = "tf-acc-vpc"
+	cidr_block = "192.168.0.0/16"
+	tags = {
+		env  = "prod"
+		team = "infra"
+	}
+}
+`
+
+func TestUnitKsyunVPC_basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "ksyun_vpc.foo",
+		Providers:     api.providers(),
+		CheckDestroy:  api.checkDestroy("vpc", "ksyun_vpc"),
+
+		Steps: []resource.TestStep{
+			{
+				Config: api.config(testAccVPCConfigTags),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("vpc", "ksyun_vpc.foo"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "vpc_name", "tf-acc-vpc"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "cidr_block", "192.168.0.0/16"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.env", "test"),
+				),
+			},
+			{
+				Config: api.config(testAccVPCConfigTagsUpdate),
+
+				Check: resource.ComposeTestCheckFunc(
+					api.checkExists("vpc", "ksyun_vpc.foo"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.%", "2"),
+					resource.TestCheckResourceAttr("ksyun_vpc.foo", "tags.env", "prod"),
+				),
+			},
+			{
+				Config:            api.config(testAccVPCConfigTagsUpdate),
+				ResourceName:      "ksyun_vpc.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
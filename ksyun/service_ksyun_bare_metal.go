@@ -73,7 +73,7 @@ func (s *BareMetalService) ReadBareMetal(d *schema.ResourceData, hostId string,
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("BareMetal %s not exist ", hostId)
+		return data, newNotFoundError(fmt.Sprintf("BareMetal %s not exist ", hostId))
 	}
 	return data, err
 }
@@ -85,7 +85,7 @@ func (s *BareMetalService) ReadAndSetBareMetal(d *schema.ResourceData, r *schema
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading address %q, %s", d.Id(), callErr))
@@ -104,6 +104,10 @@ func (s *BareMetalService) ReadAndSetBareMetal(d *schema.ResourceData, r *schema
 				}
 				delete(data, "NetworkInterfaceAttributeSet")
 			}
+			err = mergeTagsData(d, &data, s.client, "epc")
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
 			extra := map[string]SdkResponseMapping{
 				"RaidTemplateId": {
 					Field: "raid_id",
@@ -211,7 +215,7 @@ func (s *BareMetalService) ReadAndSetBareMetals(d *schema.ResourceData, r *schem
 				Field: "dns2",
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "epc", "HostId", data))
 }
 
 func (s *BareMetalService) BareMetalStateRefreshFunc(d *schema.ResourceData, hostId string, failStates []string) resource.StateRefreshFunc {
@@ -247,7 +251,7 @@ func (s *BareMetalService) CheckBareMetalState(d *schema.ResourceData, hostId st
 		Delay:      1 * time.Minute,
 		MinTimeout: 1 * time.Minute,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = s.client.waitForState(stateConf)
 	return err
 }
 
@@ -274,6 +278,8 @@ func (s *BareMetalService) CreateBareMetalCall(d *schema.ResourceData, resource
 			mapping: "ExtensionDNS2",
 		},
 		"force_re_install": {Ignore: true},
+		"tags":             {Ignore: true},
+		"tags_all":         {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, resource, false, transform, nil, SdkReqParameter{
 		onlyTransform: false,
@@ -323,6 +329,12 @@ func (s *BareMetalService) CreateBareMetal(d *schema.ResourceData, resource *sch
 		return err
 	}
 	callbacks = append(callbacks, createCall)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, resource, "epc", false, true)
+	if err != nil {
+		return err
+	}
+	callbacks = append(callbacks, tagCall)
 	// dryRun
 	return ksyunApiCallNew(callbacks, d, s.client, true)
 }
@@ -510,6 +522,8 @@ func (s *BareMetalService) ReinstallBareMetalCall(d *schema.ResourceData, resour
 		"server_ip":                    {Ignore: true},
 		"path":                         {Ignore: true},
 		"force_re_install":             {Ignore: true},
+		"tags":                         {Ignore: true},
+		"tags_all":                     {Ignore: true},
 	}
 	if d.HasChange("force_re_install") && d.Get("force_re_install").(bool) {
 		transform["image_id"] = SdkReqTransform{
@@ -720,6 +734,13 @@ func (s *BareMetalService) ModifyBareMetal(d *schema.ResourceData, resource *sch
 		return err
 	}
 	callbacks = append(callbacks, projectCall)
+
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, resource, "epc", true, false)
+	if err != nil {
+		return err
+	}
+	callbacks = append(callbacks, tagCall)
 	// dryRun
 	return ksyunApiCallNew(callbacks, d, s.client, true)
 }
@@ -741,7 +762,7 @@ func (s *BareMetalService) RemoveBareMetalCall(d *schema.ResourceData) (callback
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadBareMetal(d, "", false)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading bare metal when delete %q, %s", d.Id(), callErr))
@@ -62,7 +62,7 @@ func (s *BwsService) ReadBandWidthShare(d *schema.ResourceData, bwsId string) (d
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("BandWidthShare %s not exist ", bwsId)
+		return data, newNotFoundError(fmt.Sprintf("BandWidthShare %s not exist ", bwsId))
 	}
 	return data, err
 }
@@ -74,12 +74,16 @@ func (s *BwsService) ReadAndSetBandWidthShare(d *schema.ResourceData, r *schema.
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading bandWidthShare %q, %s", d.Id(), callErr))
 			}
 		} else {
+			err = mergeTagsData(d, &data, s.client, "bandwidthshare")
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
 			SdkResponseAutoResourceData(d, r, data, chargeExtraForVpc(data))
 			return nil
 		}
@@ -135,11 +139,15 @@ func (s *BwsService) ReadAndSetBandWidthShares(d *schema.ResourceData, r *schema
 				},
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "bandwidthshare", "BandWidthShareId", data))
 }
 
 func (s *BwsService) CreateBandWidthShareCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, false, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{false})
 	if err != nil {
 		return callback, err
 	}
@@ -170,7 +178,12 @@ func (s *BwsService) CreateBandWidthShare(d *schema.ResourceData, r *schema.Reso
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "bandwidthshare", false, true)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, tagCall}, d, s.client, true)
 }
 
 func (s *BwsService) ModifyBandWidthShareProjectCall(d *schema.ResourceData, resource *schema.Resource) (callback ApiCall, err error) {
@@ -198,6 +211,8 @@ func (s *BwsService) ModifyBandWidthShareProjectCall(d *schema.ResourceData, res
 func (s *BwsService) ModifyBandWidthShareCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
 	transform := map[string]SdkReqTransform{
 		"project_id": {Ignore: true},
+		"tags":       {Ignore: true},
+		"tags_all":   {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{
 		false,
@@ -234,7 +249,12 @@ func (s *BwsService) ModifyBandWidthShare(d *schema.ResourceData, r *schema.Reso
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{projectCall, call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "bandwidthshare", true, false)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{projectCall, call, tagCall}, d, s.client, true)
 }
 
 func (s *BwsService) RemoveBandWidthShareCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -254,7 +274,7 @@ func (s *BwsService) RemoveBandWidthShareCall(d *schema.ResourceData) (callback
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadBandWidthShare(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading bandWidthShare when delete %q, %s", d.Id(), callErr))
@@ -287,7 +307,7 @@ func (s *BwsService) ReadBandWidthShareAssociate(d *schema.ResourceData, bwsId s
 	data, err := s.ReadBandWidthShare(d, bwsId)
 	result = make(map[string]interface{})
 	if len(data["AssociateBandWidthShareInfoSet"].([]interface{})) == 0 {
-		return data, fmt.Errorf("AllocationId %s not associate in BandWidthShare %s ", allocationId, bwsId)
+		return data, newNotFoundError(fmt.Sprintf("AllocationId %s not associate in BandWidthShare %s ", allocationId, bwsId))
 	}
 
 	isFound := false
@@ -300,7 +320,7 @@ func (s *BwsService) ReadBandWidthShareAssociate(d *schema.ResourceData, bwsId s
 	}
 
 	if !isFound {
-		return data, fmt.Errorf("AllocationId %s not associate in BandWidthShare %s ", allocationId, bwsId)
+		return data, newNotFoundError(fmt.Sprintf("AllocationId %s not associate in BandWidthShare %s ", allocationId, bwsId))
 	}
 
 	result["BandWidthShareId"] = data["BandWidthShareId"]
@@ -374,7 +394,7 @@ func (s *BwsService) DisassociateBandWidthShareCall(d *schema.ResourceData) (cal
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadBandWidthShare(d, d.Get("band_width_share_id").(string))
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading bandWidthShare associate when disassociate %q, %s", d.Id(), callErr))
@@ -0,0 +1,223 @@
+package ksyun
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+)
+
+func (s *KecService) ReadDataGuardGroups(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	conn := s.client.kecconn
+	action := "DescribeDataGuardGroup"
+	logger.Debug(logger.ReqFormat, action, condition)
+	resp, err = conn.DescribeDataGuardGroup(&condition)
+	if err != nil {
+		return data, err
+	}
+	results, err = getSdkValue("DataGuardsSet", *resp)
+	if err != nil || results == nil {
+		return data, err
+	}
+	data = results.([]interface{})
+	return data, err
+}
+
+func (s *KecService) ReadDataGuardGroup(d *schema.ResourceData, dataGuardId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if dataGuardId == "" {
+		dataGuardId = d.Id()
+	}
+	req := map[string]interface{}{
+		"DataGuardId.1": dataGuardId,
+	}
+	results, err = s.ReadDataGuardGroups(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		if item, ok := v.(map[string]interface{}); ok && item["DataGuardId"] == dataGuardId {
+			data = item
+		}
+	}
+	if len(data) == 0 {
+		return data, newNotFoundError(fmt.Sprintf("DataGuardGroup %s not exist ", dataGuardId))
+	}
+	return data, err
+}
+
+func (s *KecService) ReadAndSetDataGuardGroup(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadDataGuardGroup(d, "")
+	if err != nil {
+		return err
+	}
+	extra := map[string]SdkResponseMapping{
+		"Instances": {
+			Field: "instance_ids",
+			FieldRespFunc: func(i interface{}) interface{} {
+				var instanceIds []string
+				for _, v := range i.([]interface{}) {
+					instanceIds = append(instanceIds, v.(map[string]interface{})["InstanceId"].(string))
+				}
+				return instanceIds
+			},
+		},
+	}
+	SdkResponseAutoResourceData(d, r, data, extra)
+	return err
+}
+
+func (s *KecService) ReadAndSetDataGuardGroups(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {
+			mapping: "DataGuardId",
+			Type:    TransformWithN,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.ReadDataGuardGroups(req)
+	if err != nil {
+		return err
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		nameField:   "DataGuardName",
+		idFiled:     "DataGuardId",
+		targetField: "data_guard_groups",
+		extra: map[string]SdkResponseMapping{
+			"DataGuardId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+		},
+	})
+}
+
+func (s *KecService) CreateDataGuardGroupCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, false, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateDataGuardGroup",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.CreateDataGuardGroup(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("DataGuardId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *KecService) CreateDataGuardGroup(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateDataGuardGroupCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *KecService) ModifyDataGuardGroupCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, true, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if len(req) > 0 {
+		req["DataGuardId"] = d.Id()
+		callback = ApiCall{
+			param:  &req,
+			action: "ModifyDataGuardGroups",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.kecconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = conn.ModifyDataGuardGroups(call.param)
+				return resp, err
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+				return err
+			},
+		}
+	}
+	return callback, err
+}
+
+func (s *KecService) ModifyDataGuardGroup(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.ModifyDataGuardGroupCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *KecService) RemoveDataGuardGroupCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"DataGuardId.1": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteDataGuardGroups",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.DeleteDataGuardGroups(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			// the instances may be leaving the data guard group when it is deleted with them
+			return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+				_, callErr := s.ReadDataGuardGroup(d, "")
+				if callErr != nil {
+					if isNotFoundError(callErr) {
+						return nil
+					} else {
+						return resource.NonRetryableError(fmt.Errorf("error on  reading data guard group when delete %q, %s", d.Id(), callErr))
+					}
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *KecService) RemoveDataGuardGroup(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveDataGuardGroupCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
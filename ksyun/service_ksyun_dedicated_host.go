@@ -0,0 +1,309 @@
+package ksyun
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"time"
+)
+
+type DedicatedService struct {
+	client *KsyunClient
+}
+
+// dedicatedParam formats the values of param as strings, the dedicated sdk builds its request body only from string values
+func dedicatedParam(param *map[string]interface{}) *map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range *param {
+		result[k] = fmt.Sprintf("%v", v)
+	}
+	return &result
+}
+
+func (s *DedicatedService) ReadDedicatedHosts(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	conn := s.client.dedicatedconn
+	action := "DescribeDedicatedHosts"
+	logger.Debug(logger.ReqFormat, action, condition)
+	resp, err = conn.DescribeDedicatedHosts(dedicatedParam(&condition))
+	if err != nil {
+		return data, err
+	}
+	results, err = getSdkValue("DedicatedHostSet", *resp)
+	if err != nil || results == nil {
+		return data, err
+	}
+	data = results.([]interface{})
+	return data, err
+}
+
+func (s *DedicatedService) ReadDedicatedHost(d *schema.ResourceData, hostId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if hostId == "" {
+		hostId = d.Id()
+	}
+	req := map[string]interface{}{
+		"DedicatedHostId.1": hostId,
+	}
+	err = addProjectInfoAll(d, &req, s.client)
+	if err != nil {
+		return data, err
+	}
+	results, err = s.ReadDedicatedHosts(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		if item, ok := v.(map[string]interface{}); ok && item["DedicatedHostId"] == hostId {
+			data = item
+		}
+	}
+	if len(data) == 0 {
+		return data, newNotFoundError(fmt.Sprintf("DedicatedHost %s not exist ", hostId))
+	}
+	return data, err
+}
+
+func (s *DedicatedService) dedicatedHostStateRefreshFunc(d *schema.ResourceData, hostId string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		data, err := s.ReadDedicatedHost(d, hostId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		status, err := getSdkValue("Status", data)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, v := range failStates {
+			if v == status.(string) {
+				return nil, "", fmt.Errorf("dedicated host status  error, status:%v", status)
+			}
+		}
+		return data, status.(string), nil
+	}
+}
+
+func (s *DedicatedService) checkDedicatedHostState(d *schema.ResourceData, hostId string, target []string, timeout time.Duration) (state interface{}, err error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{},
+		Target:       target,
+		Refresh:      s.dedicatedHostStateRefreshFunc(d, hostId, []string{"error"}),
+		Timeout:      timeout,
+		PollInterval: 5 * time.Second,
+		Delay:        10 * time.Second,
+		MinTimeout:   1 * time.Second,
+	}
+	return s.client.waitForState(stateConf)
+}
+
+func (s *DedicatedService) ReadAndSetDedicatedHost(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadDedicatedHost(d, "")
+	if err != nil {
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return err
+}
+
+func (s *DedicatedService) ReadAndSetDedicatedHosts(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {
+			mapping: "DedicatedHostId",
+			Type:    TransformWithN,
+		},
+		"project_id": {
+			Type: TransformWithN,
+		},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.ReadDedicatedHosts(req)
+	if err != nil {
+		return err
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		nameField:   "DedicatedHostName",
+		idFiled:     "DedicatedHostId",
+		targetField: "dedicated_hosts",
+		extra: map[string]SdkResponseMapping{
+			"DedicatedHostId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+		},
+	})
+}
+
+func (s *DedicatedService) CreateDedicatedHostCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"purchase_time": {
+			ValueFunc: func(d *schema.ResourceData) (interface{}, bool) {
+				if v, ok := d.GetOk("charge_type"); ok && (v.(string) == "Monthly" || v.(string) == "PrePaidByMonth") {
+					return d.GetOk("purchase_time")
+				}
+				return nil, false
+			},
+		},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if v, ok := d.GetOk("charge_type"); ok && (v.(string) == "Monthly" || v.(string) == "PrePaidByMonth") {
+		if _, ok := req["PurchaseTime"]; !ok {
+			return callback, fmt.Errorf("purchase_time is required when charge_type is %s", v)
+		}
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateDedicatedHosts",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.dedicatedconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.CreateDedicatedHosts(dedicatedParam(call.param))
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("DedicatedHostSet.0.DedicatedHostId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			_, err = s.checkDedicatedHostState(d, "", []string{"available"}, d.Timeout(schema.TimeoutCreate))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *DedicatedService) CreateDedicatedHost(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateDedicatedHostCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *DedicatedService) ModifyDedicatedHostProjectCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"project_id": {},
+	}
+	updateReq, err := SdkRequestAutoMapping(d, r, true, transform, nil)
+	if err != nil {
+		return callback, err
+	}
+	if len(updateReq) > 0 {
+		callback = ApiCall{
+			param: &updateReq,
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				return resp, ModifyProjectInstanceNew(d.Id(), call.param, client)
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				return err
+			},
+		}
+	}
+	return callback, err
+}
+
+func (s *DedicatedService) ModifyDedicatedHostNameCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"dedicated_host_name": {},
+	}
+	updateReq, err := SdkRequestAutoMapping(d, r, true, transform, nil)
+	if err != nil {
+		return callback, err
+	}
+	if len(updateReq) > 0 {
+		updateReq["DedicatedHostId"] = d.Id()
+		callback = ApiCall{
+			param:  &updateReq,
+			action: "RenameDedicatedHost",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.dedicatedconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = conn.RenameDedicatedHost(dedicatedParam(call.param))
+				return resp, err
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+				return err
+			},
+		}
+	}
+	return callback, err
+}
+
+func (s *DedicatedService) ModifyDedicatedHost(d *schema.ResourceData, r *schema.Resource) (err error) {
+	projectCall, err := s.ModifyDedicatedHostProjectCall(d, r)
+	if err != nil {
+		return err
+	}
+	nameCall, err := s.ModifyDedicatedHostNameCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{projectCall, nameCall}, d, s.client, true)
+}
+
+func (s *DedicatedService) RemoveDedicatedHostCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"DedicatedHostId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteDedicatedHost",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.dedicatedconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.DeleteDedicatedHost(dedicatedParam(call.param))
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			// the instances on the host may be still terminating when the host is released with them
+			return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+				_, callErr := s.ReadDedicatedHost(d, "")
+				if callErr != nil {
+					if isNotFoundError(callErr) {
+						return nil
+					} else {
+						return resource.NonRetryableError(fmt.Errorf("error on  reading dedicated host when delete %q, %s", d.Id(), callErr))
+					}
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *DedicatedService) RemoveDedicatedHost(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveDedicatedHostCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
@@ -57,7 +57,7 @@ func (s *EipService) ReadAddress(d *schema.ResourceData, allocationId string) (d
 	if err != nil {
 		return data, err
 	}
-	results, err = s.ReadAddresses(req)
+	results, err = s.client.batchDescribe("DescribeAddresses", "AllocationId", req, s.ReadAddresses)
 	if err != nil {
 		return data, err
 	}
@@ -65,7 +65,7 @@ func (s *EipService) ReadAddress(d *schema.ResourceData, allocationId string) (d
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Address %s not exist ", allocationId)
+		return data, newNotFoundError(fmt.Sprintf("Address %s not exist ", allocationId))
 	}
 	return data, err
 }
@@ -77,7 +77,7 @@ func (s *EipService) ReadAndSetAddress(d *schema.ResourceData, r *schema.Resourc
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading address %q, %s", d.Id(), callErr))
@@ -147,12 +147,13 @@ func (s *EipService) ReadAndSetAddresses(d *schema.ResourceData, r *schema.Resou
 				KeepAuto: true,
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "eip", "AllocationId", data))
 }
 
 func (s *EipService) CreateAddressCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
 	transform := map[string]SdkReqTransform{
-		"tags": {Ignore: true},
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
 		onlyTransform: false,
@@ -221,6 +222,7 @@ func (s *EipService) ModifyAddressCall(d *schema.ResourceData, r *schema.Resourc
 	transform := map[string]SdkReqTransform{
 		"project_id": {Ignore: true},
 		"tags":       {Ignore: true},
+		"tags_all":   {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{
 		false,
@@ -282,7 +284,7 @@ func (s *EipService) RemoveAddressCall(d *schema.ResourceData) (callback ApiCall
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadAddress(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading address when delete %q, %s", d.Id(), callErr))
@@ -315,18 +317,18 @@ func (s *EipService) ReadAddressAssociate(d *schema.ResourceData, allocationId s
 	data, err = s.ReadAddress(d, allocationId)
 	if id, ok := data["InstanceId"]; ok {
 		if id != instanceId {
-			return data, fmt.Errorf("InstanceId %s not associate in Address %s ", instanceId, allocationId)
+			return data, newNotFoundError(fmt.Sprintf("InstanceId %s not associate in Address %s ", instanceId, allocationId))
 		}
 	} else {
-		return data, fmt.Errorf("InstanceId %s not associate in Address %s ", instanceId, allocationId)
+		return data, newNotFoundError(fmt.Sprintf("InstanceId %s not associate in Address %s ", instanceId, allocationId))
 	}
 	if networkInterfaceId != "" {
 		if vifId, ok := data["NetworkInterfaceId"]; ok {
 			if vifId != networkInterfaceId {
-				return data, fmt.Errorf("InstanceId %s not associate in Address %s ", instanceId, allocationId)
+				return data, newNotFoundError(fmt.Sprintf("InstanceId %s not associate in Address %s ", instanceId, allocationId))
 			}
 		} else {
-			return data, fmt.Errorf("InstanceId %s not associate in Address %s ", instanceId, allocationId)
+			return data, newNotFoundError(fmt.Sprintf("InstanceId %s not associate in Address %s ", instanceId, allocationId))
 		}
 	}
 	return data, err
@@ -389,7 +391,7 @@ func (s *EipService) RemoveAddressAssociateCall(d *schema.ResourceData, allocati
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadAddressAssociate(d, allocationId, instanceId, networkInterfaceId)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading address associate when delete %q, %s", d.Id(), callErr))
@@ -1,8 +1,12 @@
 package ksyun
 
 import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"sort"
+	"time"
 )
 
 type ImageService struct {
@@ -33,7 +37,7 @@ func (s *ImageService) readKecImages(condition map[string]interface{}) (data []i
 	}
 
 	results, err = getSdkValue("ImagesSet", *resp)
-	if err != nil {
+	if err != nil || results == nil {
 		return data, err
 	}
 	data = results.([]interface{})
@@ -97,3 +101,455 @@ func (s *ImageService) ReadAndSetKecImages(d *schema.ResourceData, r *schema.Res
 		return result, flag, err
 	})
 }
+
+func (s *ImageService) ReadImage(d *schema.ResourceData, imageId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if imageId == "" {
+		imageId = d.Id()
+	}
+	req := map[string]interface{}{
+		"ImageId": imageId,
+	}
+	results, err = s.readKecImages(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		if item, ok := v.(map[string]interface{}); ok && item["ImageId"] == imageId {
+			data = item
+		}
+	}
+	if len(data) == 0 {
+		return data, newNotFoundError(fmt.Sprintf("Image %s not exist ", imageId))
+	}
+	return data, err
+}
+
+// ReadImageShareAccounts returns the account ids the image is shared with
+func (s *ImageService) ReadImageShareAccounts(imageId string) (accounts []interface{}, err error) {
+	conn := s.client.kecconn
+	req := map[string]interface{}{
+		"ImageId": imageId,
+	}
+	action := "DescribeImageSharePermission"
+	logger.Debug(logger.ReqFormat, action, req)
+	resp, err := conn.DescribeImageSharePermission(&req)
+	if err != nil {
+		return accounts, err
+	}
+	results, err := getSdkValue("AccountIdSet", *resp)
+	if err != nil || results == nil {
+		return accounts, err
+	}
+	for _, v := range results.([]interface{}) {
+		if item, ok := v.(map[string]interface{}); ok {
+			v = item["AccountId"]
+		}
+		if v != nil {
+			accounts = append(accounts, fmt.Sprintf("%v", v))
+		}
+	}
+	return accounts, err
+}
+
+// ReadImageCopies returns the copies which still exist in their regions, the copies are described by their ids
+// since the api does not link a copy to its source image, and the copies removed outside of terraform are dropped
+// so their regions are copied again
+func (s *ImageService) ReadImageCopies(copies map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for region, id := range copies {
+		conn := s.client.kecRegionConn(region)
+		req := map[string]interface{}{
+			"ImageId": id,
+		}
+		action := "DescribeImages"
+		logger.Debug(logger.ReqFormat, action, req)
+		resp, err := conn.DescribeImages(&req)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return result, fmt.Errorf("error on reading the copy %s of image in region %s, %s", id, region, err)
+		}
+		images, _ := getSdkValue("ImagesSet", *resp)
+		items, _ := images.([]interface{})
+		for _, item := range items {
+			if image, ok := item.(map[string]interface{}); ok && image["ImageId"] == id {
+				result[region] = id
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *ImageService) imageStateRefreshFunc(d *schema.ResourceData, imageId string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		data, err := s.ReadImage(d, imageId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		status, err := getSdkValue("ImageState", data)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, v := range failStates {
+			if v == status.(string) {
+				return nil, "", fmt.Errorf("image status  error, status:%v", status)
+			}
+		}
+		return data, status.(string), nil
+	}
+}
+
+func (s *ImageService) checkImageState(d *schema.ResourceData, imageId string, target []string, timeout time.Duration) (state interface{}, err error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{},
+		Target:       target,
+		Refresh:      s.imageStateRefreshFunc(d, imageId, []string{"error"}),
+		Timeout:      timeout,
+		PollInterval: 5 * time.Second,
+		Delay:        10 * time.Second,
+		MinTimeout:   1 * time.Second,
+	}
+	return s.client.waitForState(stateConf)
+}
+
+func (s *ImageService) ReadAndSetImage(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadImage(d, "")
+	if err != nil {
+		return err
+	}
+	accounts, err := s.ReadImageShareAccounts(d.Id())
+	if err != nil {
+		return err
+	}
+	data["ShareAccountIds"] = accounts
+	copies, err := s.ReadImageCopies(d.Get("copy_image_ids").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+	var regions []interface{}
+	for region := range copies {
+		regions = append(regions, region)
+	}
+	data["CopyImageIds"] = copies
+	data["CopyRegions"] = regions
+	SdkResponseAutoResourceData(d, r, data, map[string]SdkResponseMapping{
+		"Name": {
+			Field: "image_name",
+		},
+	})
+	return err
+}
+
+func (s *ImageService) CreateImageCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	transform := map[string]SdkReqTransform{
+		"image_name": {
+			mapping: "Name",
+		},
+		"data_disk_ids": {
+			mapping: "DataDiskIds",
+			Type:    TransformWithN,
+		},
+		"copy_regions":      {Ignore: true},
+		"copy_image_ids":    {Ignore: true},
+		"share_account_ids": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateImage",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.CreateImage(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("ImageId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			_, err = s.checkImageState(d, "", []string{"active"}, d.Timeout(schema.TimeoutCreate))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *ImageService) CreateImage(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateImageCall(d, r)
+	if err != nil {
+		return err
+	}
+	shareCalls, err := s.ModifyImageShareCalls(d, false)
+	if err != nil {
+		return err
+	}
+	copyCalls, err := s.CopyImageCalls(d, false)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew(append(append([]ApiCall{call}, shareCalls...), copyCalls...), d, s.client, true)
+}
+
+func (s *ImageService) ModifyImageAttributeCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	if !d.HasChange("image_name") {
+		return callback, err
+	}
+	req := map[string]interface{}{
+		"ImageId": d.Id(),
+		"Name":    d.Get("image_name"),
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "ModifyImageAttribute",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.ModifyImageAttribute(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+// ModifyImageShareCalls shares the image with the added accounts of share_account_ids and cancels the removed ones
+func (s *ImageService) ModifyImageShareCalls(d *schema.ResourceData, isUpdate bool) (callbacks []ApiCall, err error) {
+	if isUpdate && !d.HasChange("share_account_ids") {
+		return callbacks, err
+	}
+	o, n := d.GetChange("share_account_ids")
+	oldSet, newSet := o.(*schema.Set), n.(*schema.Set)
+	if !isUpdate {
+		oldSet = schema.NewSet(schema.HashString, nil)
+	}
+	if cancels := SchemaSetToStringSlice(oldSet.Difference(newSet)); len(cancels) > 0 {
+		callbacks = append(callbacks, s.imageSharePermissionCall("cancel", cancels, isUpdate))
+	}
+	if shares := SchemaSetToStringSlice(newSet.Difference(oldSet)); len(shares) > 0 {
+		callbacks = append(callbacks, s.imageSharePermissionCall("share", shares, isUpdate))
+	}
+	return callbacks, err
+}
+
+func (s *ImageService) imageSharePermissionCall(permission string, accounts []string, isUpdate bool) ApiCall {
+	req := map[string]interface{}{
+		"Permission": permission,
+	}
+	for i, account := range accounts {
+		req[fmt.Sprintf("AccountId.%d", i+1)] = account
+	}
+	return ApiCall{
+		param:         &req,
+		action:        "ModifyImageSharePermission",
+		disableDryRun: !isUpdate,
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecconn
+			(*call.param)["ImageId.1"] = d.Id()
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.ModifyImageSharePermission(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+}
+
+// CopyImageCalls copies the image to each added region of copy_regions and removes the copies of the removed regions,
+// the ids of the copies are kept in copy_image_ids by region
+func (s *ImageService) CopyImageCalls(d *schema.ResourceData, isUpdate bool) (callbacks []ApiCall, err error) {
+	if isUpdate && !d.HasChange("copy_regions") {
+		return callbacks, err
+	}
+	o, n := d.GetChange("copy_regions")
+	oldSet, newSet := o.(*schema.Set), n.(*schema.Set)
+	if !isUpdate {
+		oldSet = schema.NewSet(schema.HashString, nil)
+	}
+	copies := d.Get("copy_image_ids").(map[string]interface{})
+	for _, region := range SchemaSetToStringSlice(oldSet.Difference(newSet)) {
+		if id, ok := copies[region].(string); ok && id != "" {
+			callbacks = append(callbacks, s.removeImageCopyCall(region, id))
+		}
+	}
+	for _, region := range SchemaSetToStringSlice(newSet.Difference(oldSet)) {
+		callbacks = append(callbacks, s.copyImageCall(region, isUpdate))
+	}
+	return callbacks, err
+}
+
+func (s *ImageService) copyImageCall(region string, isUpdate bool) ApiCall {
+	req := map[string]interface{}{
+		"DestinationRegion.1": region,
+	}
+	return ApiCall{
+		param:         &req,
+		action:        "CopyImage",
+		disableDryRun: !isUpdate,
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecconn
+			(*call.param)["ImageId.1"] = d.Id()
+			(*call.param)["DestinationImageName"] = d.Get("image_name")
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.CopyImage(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id := copiedImageId(*resp)
+			if id == "" {
+				return fmt.Errorf("the id of the image copy in region %s is not returned", region)
+			}
+			return setImageCopy(d, region, id)
+		},
+	}
+}
+
+// removeImageCopyCall removes the copy of the image in region by the kec connection of the region
+func (s *ImageService) removeImageCopyCall(region string, id string) ApiCall {
+	req := map[string]interface{}{
+		"ImageId.1": id,
+	}
+	return ApiCall{
+		param:  &req,
+		action: "RemoveImages",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecRegionConn(region)
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.RemoveImages(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			// the copy removed outside of terraform is removed from copy_image_ids by afterCall
+			if isNotFoundError(baseErr) {
+				return nil
+			}
+			return baseErr
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			return setImageCopy(d, region, "")
+		},
+	}
+}
+
+// setImageCopy sets the copy id of region in copy_image_ids, the empty id removes the region
+func setImageCopy(d *schema.ResourceData, region string, id string) error {
+	copies := make(map[string]interface{})
+	for k, v := range d.Get("copy_image_ids").(map[string]interface{}) {
+		copies[k] = v
+	}
+	if id == "" {
+		delete(copies, region)
+	} else {
+		copies[region] = id
+	}
+	return d.Set("copy_image_ids", copies)
+}
+
+// copiedImageId returns the id of the copy in the CopyImage response of one region,
+// which is ImageId of the response or of the first item in its image set
+func copiedImageId(resp map[string]interface{}) string {
+	if id, ok := resp["ImageId"].(string); ok {
+		return id
+	}
+	for _, v := range resp {
+		if items, ok := v.([]interface{}); ok && len(items) > 0 {
+			if item, ok := items[0].(map[string]interface{}); ok {
+				if id, ok := item["ImageId"].(string); ok {
+					return id
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (s *ImageService) ModifyImage(d *schema.ResourceData) (err error) {
+	call, err := s.ModifyImageAttributeCall(d)
+	if err != nil {
+		return err
+	}
+	shareCalls, err := s.ModifyImageShareCalls(d, true)
+	if err != nil {
+		return err
+	}
+	copyCalls, err := s.CopyImageCalls(d, true)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew(append(append([]ApiCall{call}, shareCalls...), copyCalls...), d, s.client, true)
+}
+
+func (s *ImageService) RemoveImageCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"ImageId.1": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "RemoveImages",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.kecconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.RemoveImages(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+				_, callErr := s.ReadImage(d, "")
+				if callErr != nil {
+					if isNotFoundError(callErr) {
+						return nil
+					} else {
+						return resource.NonRetryableError(fmt.Errorf("error on  reading image when delete %q, %s", d.Id(), callErr))
+					}
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *ImageService) RemoveImage(d *schema.ResourceData) (err error) {
+	var calls []ApiCall
+	copies := d.Get("copy_image_ids").(map[string]interface{})
+	var regions []string
+	for region := range copies {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	for _, region := range regions {
+		calls = append(calls, s.removeImageCopyCall(region, copies[region].(string)))
+	}
+	call, err := s.RemoveImageCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew(append(calls, call), d, s.client, true)
+}
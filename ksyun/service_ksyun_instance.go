@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -20,7 +21,7 @@ func (s *KecService) readAndSetKecInstance(d *schema.ResourceData, r *schema.Res
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading instane %q, %s", d.Id(), callErr))
@@ -150,7 +151,7 @@ func (s *KecService) ReadAndSetKecInstances(d *schema.ResourceData, r *schema.Re
 	if err != nil {
 		return err
 	}
-	data, err := s.readKecInstances(req)
+	data, err := s.readKecInstancesPage(req, dataSourcePageLimit(d))
 	if err != nil {
 		return err
 	}
@@ -165,7 +166,7 @@ func (s *KecService) ReadAndSetKecInstances(d *schema.ResourceData, r *schema.Re
 				Field: "key_id",
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "instance", "InstanceId", data))
 }
 
 func (s *KecService) readKecNetworkInterface(networkInterfaceId string) (data map[string]interface{}, err error) {
@@ -186,7 +187,7 @@ func (s *KecService) readKecNetworkInterface(networkInterfaceId string) (data ma
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Kec network interface %s not exist ", networkInterfaceId)
+		return data, newNotFoundError(fmt.Sprintf("Kec network interface %s not exist ", networkInterfaceId))
 	}
 	return data, err
 }
@@ -213,7 +214,7 @@ func (s *KecService) readKecInstance(d *schema.ResourceData, instanceId string,
 		}
 	}
 
-	kecInstanceResults, err = s.readKecInstances(req)
+	kecInstanceResults, err = s.client.batchDescribe("DescribeInstances", "InstanceId", req, s.readKecInstances)
 	if err != nil {
 		return data, err
 	}
@@ -221,38 +222,31 @@ func (s *KecService) readKecInstance(d *schema.ResourceData, instanceId string,
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Kec instance %s not exist ", instanceId)
+		return data, newNotFoundError(fmt.Sprintf("Kec instance %s not exist ", instanceId))
 	}
 	return data, err
 }
 
 func (s *KecService) readKecInstances(condition map[string]interface{}) (data []interface{}, err error) {
-	var (
-		resp    *map[string]interface{}
-		results interface{}
-	)
-	return pageQuery(condition, "MaxResults", "Marker", 200, 0, func(condition map[string]interface{}) ([]interface{}, error) {
+	return s.readKecInstancesPage(condition, 0)
+}
+
+// readKecInstancesPage lists at most maxResults instances, 0 means all the instances
+func (s *KecService) readKecInstancesPage(condition map[string]interface{}, maxResults int) (data []interface{}, err error) {
+	return pageQueryResponse(condition, pageResponseOptions{
+		pageOptions: pageOptions{
+			limitParam: "MaxResults",
+			pageParam:  "Marker",
+			limit:      200,
+			maxResults: maxResults,
+		},
+		itemsField: "InstancesSet",
+		totalField: "InstanceCount",
+	}, func(condition map[string]interface{}) (*map[string]interface{}, error) {
 		conn := s.client.kecconn
 		action := "DescribeInstances"
 		logger.Debug(logger.ReqFormat, action, condition)
-		if condition == nil {
-			resp, err = conn.DescribeInstances(nil)
-			if err != nil {
-				return data, err
-			}
-		} else {
-			resp, err = conn.DescribeInstances(&condition)
-			if err != nil {
-				return data, err
-			}
-		}
-
-		results, err = getSdkValue("InstancesSet", *resp)
-		if err != nil {
-			return data, err
-		}
-		data = results.([]interface{})
-		return data, err
+		return conn.DescribeInstances(&condition)
 	})
 }
 
@@ -337,6 +331,13 @@ func (s *KecService) modifyKecInstance(d *schema.ResourceData, resource *schema.
 		return err
 	}
 	callbacks = append(callbacks, roleCall)
+	//data guard
+	leaveCall, joinCall, err := s.modifyKecInstanceDataGuard(d)
+	if err != nil {
+		return err
+	}
+	callbacks = append(callbacks, leaveCall)
+	callbacks = append(callbacks, joinCall)
 	//network update
 	networkCall, err := s.modifyKecInstanceNetwork(d, resource)
 	if err != nil {
@@ -455,6 +456,7 @@ func (s *KecService) createKecInstanceCommon(d *schema.ResourceData, resource *s
 		"force_delete":           {Ignore: true},
 		"force_reinstall_system": {Ignore: true},
 		"tags":                   {Ignore: true},
+		"tags_all":               {Ignore: true},
 	}
 	createReq, err := SdkRequestAutoMapping(d, resource, false, transform, nil, SdkReqParameter{
 		onlyTransform: false,
@@ -596,6 +598,55 @@ func (s *KecService) modifyKecInstanceIamRole(d *schema.ResourceData) (callback
 	return callback, err
 }
 
+// modifyKecInstanceDataGuard moves the instance from the old data guard group to the new one in place
+func (s *KecService) modifyKecInstanceDataGuard(d *schema.ResourceData) (leave ApiCall, join ApiCall, err error) {
+	if !d.HasChange("data_guard_id") {
+		return leave, join, err
+	}
+	o, n := d.GetChange("data_guard_id")
+	if o != "" {
+		leaveReq := map[string]interface{}{
+			"DataGuardId":  o,
+			"InstanceId.1": d.Id(),
+		}
+		leave = ApiCall{
+			param:  &leaveReq,
+			action: "RemoveVmFromDataGuard",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.kecconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = conn.RemoveVmFromDataGuard(call.param)
+				return resp, err
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+				return err
+			},
+		}
+	}
+	if n != "" {
+		joinReq := map[string]interface{}{
+			"DataGuardId":  n,
+			"InstanceId.1": d.Id(),
+		}
+		join = ApiCall{
+			param:  &joinReq,
+			action: "AddVmIntoDataGuard",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.kecconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = conn.AddVmIntoDataGuard(call.param)
+				return resp, err
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+				return err
+			},
+		}
+	}
+	return leave, join, err
+}
+
 func (s *KecService) modifyKecInstanceProject(d *schema.ResourceData, resource *schema.Resource) (callback ApiCall, err error) {
 	transform := map[string]SdkReqTransform{
 		"project_id": {},
@@ -1106,7 +1157,7 @@ func (s *KecService) removeKecInstance(d *schema.ResourceData, meta interface{})
 		}
 		_, err = s.readKecInstance(d, "", false)
 		if err != nil {
-			if notFoundError(err) {
+			if isNotFoundError(err) {
 				return nil
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading instance when delete %q, %s", d.Id(), err))
@@ -1126,7 +1177,7 @@ func (s *KecService) checkKecInstanceState(d *schema.ResourceData, instanceId st
 		Delay:        10 * time.Second,
 		MinTimeout:   1 * time.Second,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = s.client.waitForState(stateConf)
 	return err
 }
 
@@ -1301,10 +1352,10 @@ func (s *KecService) readAndSetNetworkInterfaceAttachment(d *schema.ResourceData
 	}
 	if id, ok := data["InstanceId"]; ok {
 		if id != d.Get("instance_id") {
-			return fmt.Errorf("Network interface attachmemt %s not exist ", d.Id())
+			return newNotFoundError(fmt.Sprintf("Network interface attachmemt %s not exist ", d.Id()))
 		}
 	} else {
-		return fmt.Errorf("Network interface attachmemt %s not exist ", d.Id())
+		return newNotFoundError(fmt.Sprintf("Network interface attachmemt %s not exist ", d.Id()))
 	}
 	SdkResponseAutoResourceData(d, resource, data, nil)
 	return err
@@ -1370,3 +1421,161 @@ func (s *KecService) modifyNetworkInterfaceAttachment(d *schema.ResourceData, re
 	}
 	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
 }
+
+func (s *KecService) readKecInstanceTypes(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	conn := s.client.kecconn
+	action := "DescribeInstanceTypeConfigs"
+	logger.Debug(logger.ReqFormat, action, condition)
+	resp, err = conn.DescribeInstanceTypeConfigs(&condition)
+	if err != nil {
+		return data, err
+	}
+	results, err = getSdkValue("InstanceTypeConfigSet", *resp)
+	if err != nil || results == nil {
+		return data, err
+	}
+	data = results.([]interface{})
+	return data, err
+}
+
+func (s *KecService) ReadAndSetKecInstanceTypes(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"instance_families": {
+			mapping: "instance-family",
+			Type:    TransformWithFilter,
+		},
+		"availability_zone": {Ignore: true},
+		"min_cpu":           {Ignore: true},
+		"max_cpu":           {Ignore: true},
+		"min_memory":        {Ignore: true},
+		"max_memory":        {Ignore: true},
+		"min_gpu":           {Ignore: true},
+		"max_gpu":           {Ignore: true},
+		"gpu_model":         {Ignore: true},
+		"sort_by":           {Ignore: true},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	data, err := s.readKecInstanceTypes(req)
+	if err != nil {
+		return err
+	}
+	sortKecInstanceTypes(data, d.Get("sort_by").(string))
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		idFiled:     "InstanceType",
+		nameField:   "InstanceType",
+		targetField: "instance_types",
+		extra: map[string]SdkResponseMapping{
+			"InstanceType": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+			"CPU": {
+				Field: "cpu",
+			},
+			"GPU": {
+				Field: "gpu",
+			},
+			"AvailabilityZoneSet": {
+				Field: "availability_zones",
+				FieldRespFunc: func(i interface{}) interface{} {
+					return kecInstanceTypeZones(map[string]interface{}{"AvailabilityZoneSet": i})
+				},
+			},
+		},
+	}, kecInstanceTypesMatchPlugin)
+}
+
+// kecInstanceTypesMatchPlugin filters the instance types by the availability zone, cpu, memory and gpu on the client side,
+// the api only supports to filter them by the instance family
+func kecInstanceTypesMatchPlugin(d *schema.ResourceData, m map[string]interface{}) (result map[string]interface{}, flag bool, err error) {
+	flag = true
+	if zone, ok := d.GetOk("availability_zone"); ok {
+		found := false
+		for _, v := range kecInstanceTypeZones(m) {
+			if v == zone {
+				found = true
+			}
+		}
+		if !found {
+			return nil, flag, err
+		}
+	}
+	ranges := []struct {
+		min, max, field string
+	}{
+		{"min_cpu", "max_cpu", "CPU"},
+		{"min_memory", "max_memory", "Memory"},
+		{"min_gpu", "max_gpu", "GPU"},
+	}
+	for _, r := range ranges {
+		value := kecInstanceTypeNumber(m, r.field)
+		if v, ok := d.GetOk(r.min); ok && value < float64(v.(int)) {
+			return nil, flag, err
+		}
+		// max_gpu = 0 is meaningful, it lists the instance types without gpu
+		if v, ok := d.GetOkExists(r.max); ok && value > float64(v.(int)) {
+			return nil, flag, err
+		}
+	}
+	if model, ok := d.GetOk("gpu_model"); ok && m["GpuModel"] != model {
+		return nil, flag, err
+	}
+	return m, flag, err
+}
+
+// sortKecInstanceTypes sorts the instance types ascending by the cpu or memory, the other one breaks the tie
+func sortKecInstanceTypes(data []interface{}, sortBy string) {
+	var fields []string
+	switch sortBy {
+	case "cpu":
+		fields = []string{"CPU", "Memory"}
+	case "memory":
+		fields = []string{"Memory", "CPU"}
+	default:
+		return
+	}
+	sort.SliceStable(data, func(i, j int) bool {
+		for _, field := range fields {
+			a := kecInstanceTypeNumber(data[i].(map[string]interface{}), field)
+			b := kecInstanceTypeNumber(data[j].(map[string]interface{}), field)
+			if a != b {
+				return a < b
+			}
+		}
+		return false
+	})
+}
+
+func kecInstanceTypeZones(m map[string]interface{}) []string {
+	var zones []string
+	if items, ok := m["AvailabilityZoneSet"].([]interface{}); ok {
+		for _, item := range items {
+			if zone, ok := item.(map[string]interface{}); ok {
+				zones = append(zones, fmt.Sprintf("%v", zone["AzCode"]))
+			}
+		}
+	}
+	return zones
+}
+
+func kecInstanceTypeNumber(m map[string]interface{}, field string) float64 {
+	switch v := m[field].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
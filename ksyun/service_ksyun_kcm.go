@@ -59,7 +59,7 @@ func (s *KcmService) ReadCertificate(d *schema.ResourceData, certificateId strin
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Certificate %s not exist ", certificateId)
+		return data, newNotFoundError(fmt.Sprintf("Certificate %s not exist ", certificateId))
 	}
 	return data, err
 }
@@ -197,7 +197,7 @@ func (s *KcmService) RemoveCertificateCall(d *schema.ResourceData) (callback Api
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadCertificate(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading certificate when delete %q, %s", d.Id(), callErr))
@@ -103,7 +103,7 @@ func readKrdsInstance(d *schema.ResourceData, meta interface{}, instanceId strin
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Krds instance %s not exist ", instanceId)
+		return data, newNotFoundError(fmt.Sprintf("Krds instance %s not exist ", instanceId))
 	}
 	return data, err
 }
@@ -121,6 +121,10 @@ func readAndSetKrdsInstance(d *schema.ResourceData, meta interface{}, isRR bool)
 	if dbInstanceType != "RR" && isRR {
 		return fmt.Errorf("krds instance is not  read replica, please use ksyun_krds ")
 	}
+	err = mergeTagsData(d, &data, meta.(*KsyunClient), "krds")
+	if err != nil {
+		return err
+	}
 	if _, ok := data["Eip"]; ok {
 		data["instance_has_eip"] = true
 	} else {
@@ -562,7 +566,7 @@ func removeKrdsParameterGroup(d *schema.ResourceData, meta interface{}) (err err
 			delParam["DBParameterGroupId"] = d.Get("db_parameter_group_id").(string)
 			_, deleteErr := conn.DeleteDBParameterGroup(&delParam)
 			//logger.Debug("test %s %s %s", "DeleteDBParameterGroup", inUseError(deleteErr), deleteErr)
-			if deleteErr == nil || notFoundErrorNew(deleteErr) || inUseError(deleteErr) {
+			if deleteErr == nil || isNotFoundError(deleteErr) || inUseError(deleteErr) {
 				return nil
 			} else {
 				return resource.RetryableError(deleteErr)
@@ -661,6 +665,12 @@ func createKrdsInstance(d *schema.ResourceData, meta interface{}, isRR bool) (er
 		return err
 	}
 	api = append(api, eipCall)
+	// process tags
+	tagCall, err := krdsTagsCall(d, meta, isRR, false)
+	if err != nil {
+		return err
+	}
+	api = append(api, tagCall)
 	// api call
 	err = ksyunApiCall(api, d, meta)
 	if err != nil {
@@ -669,6 +679,15 @@ func createKrdsInstance(d *schema.ResourceData, meta interface{}, isRR bool) (er
 	return err
 }
 
+func krdsTagsCall(d *schema.ResourceData, meta interface{}, isRR bool, isUpdate bool) (call ksyunApiCallFunc, err error) {
+	r := resourceKsyunKrds()
+	if isRR {
+		r = resourceKsyunKrdsRr()
+	}
+	tagService := TagService{meta.(*KsyunClient)}
+	return tagService.ReplaceResourcesTagsWithResourceFunc(d, r, "krds", isUpdate)
+}
+
 func validDbInstanceClass() schema.SchemaValidateFunc {
 	return func(i interface{}, s string) (warnings []string, errors []error) {
 		config := strings.Split(i.(string), "|")
@@ -701,6 +720,8 @@ func createKrdsDbInstance(d *schema.ResourceData, meta interface{}) (call ksyunA
 		"force_restart":         {Ignore: true},
 		"availability_zone_1":   {mapping: "AvailabilityZone.1"},
 		"availability_zone_2":   {mapping: "AvailabilityZone.2"},
+		"tags":                  {Ignore: true},
+		"tags_all":              {Ignore: true},
 	}
 
 	createReq, err := SdkRequestAutoMapping(d, resourceKsyunKrds(), false, transform, nil, SdkReqParameter{
@@ -749,6 +770,8 @@ func createKrdsRrInstance(d *schema.ResourceData, meta interface{}) (call ksyunA
 		"instance_has_eip":       {Ignore: true},
 		"parameters":             {Ignore: true},
 		"force_restart":          {Ignore: true},
+		"tags":                   {Ignore: true},
+		"tags_all":               {Ignore: true},
 	}
 
 	createReq, err := SdkRequestAutoMapping(d, resourceKsyunKrdsRr(), false, transform, nil, SdkReqParameter{
@@ -816,7 +839,7 @@ func removeKrdsDbInstance(d *schema.ResourceData, meta interface{}) (err error)
 		logger.Debug(logger.ReqFormat, action, req)
 		_, err = conn.DescribeDBInstances(&req)
 		if err != nil {
-			if notFoundError(err) {
+			if isNotFoundError(err) {
 				return nil
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading krds when delete %q, %s", d.Id(), err))
@@ -889,6 +912,12 @@ func modifyKrdsInstance(d *schema.ResourceData, meta interface{}, isRR bool) (er
 		}
 		call = append(call, modifyParametersCall)
 	}
+	//ReplaceResourcesTags
+	tagCall, err := krdsTagsCall(d, meta, isRR, true)
+	if err != nil {
+		return err
+	}
+	call = append(call, tagCall)
 	err = ksyunApiCall(call, d, meta)
 	if err != nil {
 		return err
@@ -1198,7 +1227,7 @@ func checkKrdsInstanceState(d *schema.ResourceData, meta interface{}, instanceId
 		Delay:      10 * time.Second,
 		MinTimeout: 1 * time.Minute,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = meta.(*KsyunClient).waitForState(stateConf)
 	return err
 }
 
@@ -1329,7 +1358,7 @@ func removeKrdsSecurityGroupRule(d *schema.ResourceData, meta interface{}) (err
 		action := "ModifySecurityGroupRule"
 		logger.Debug(logger.ReqFormat, action, req)
 		_, err = conn.ModifySecurityGroupRule(&req)
-		if err == nil || notFoundErrorNew(err) {
+		if err == nil || isNotFoundError(err) {
 			return nil
 		} else {
 			return resource.RetryableError(err)
@@ -1596,7 +1625,7 @@ func removeKrdsSecurityGroup(d *schema.ResourceData, meta interface{}) (err erro
 		action := "DeleteSecurityGroup"
 		logger.Debug(logger.ReqFormat, action, req)
 		_, err = conn.DeleteSecurityGroup(&req)
-		if err == nil || notFoundErrorNew(err) {
+		if err == nil || isNotFoundError(err) {
 			return nil
 		} else {
 			return resource.RetryableError(err)
@@ -2,7 +2,6 @@ package ksyun
 
 import (
 	"fmt"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
@@ -187,6 +186,8 @@ func createMongodbInstance(d *schema.ResourceData, meta interface{}, r *schema.R
 		"cidrs": {
 			Ignore: true,
 		},
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
 		onlyTransform: false,
@@ -243,7 +244,7 @@ func checkMongodbState(d *schema.ResourceData, meta interface{}, instanceId stri
 		Delay:      10 * time.Second,
 		MinTimeout: 1 * time.Minute,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = meta.(*KsyunClient).waitForState(stateConf)
 	return err
 }
 
@@ -499,6 +500,15 @@ func modifyMongodbInstanceNodeNum(d *schema.ResourceData, meta interface{}, r *s
 	return err
 }
 
+func modifyMongodbInstanceTags(d *schema.ResourceData, meta interface{}, r *schema.Resource, isUpdate bool) (err error) {
+	tagService := TagService{meta.(*KsyunClient)}
+	call, err := tagService.ReplaceResourcesTagsWithResourceFunc(d, r, "mongodb", isUpdate)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCall([]ksyunApiCallFunc{call}, d, meta)
+}
+
 func modifyMongodbInstanceCommon(d *schema.ResourceData, meta interface{}, r *schema.Resource) (err error) {
 	//valid cidrs
 	err, addV4, delV4, addV6, delV6 := checkMongodbSecurityGroupRulesChange(d, meta, "cidrs", "")
@@ -540,6 +550,11 @@ func modifyMongodbInstanceCommon(d *schema.ResourceData, meta interface{}, r *sc
 	if err != nil {
 		return fmt.Errorf("error on update instance %q, %s", d.Id(), err)
 	}
+	// modify tags if need
+	err = modifyMongodbInstanceTags(d, meta, r, true)
+	if err != nil {
+		return fmt.Errorf("error on update instance %q, %s", d.Id(), err)
+	}
 	return err
 }
 
@@ -569,6 +584,11 @@ func createMongodbInstanceCommon(d *schema.ResourceData, meta interface{}, r *sc
 	if err != nil {
 		return fmt.Errorf("error on creating instance: %s", err)
 	}
+	// set tags if need
+	err = modifyMongodbInstanceTags(d, meta, r, false)
+	if err != nil {
+		return fmt.Errorf("error on creating instance: %s", err)
+	}
 	return err
 }
 
@@ -578,6 +598,10 @@ func readMongodbInstanceCommon(d *schema.ResourceData, meta interface{}, r *sche
 	)
 	data, err := readMongodbInstance(d, meta, "")
 	if err != nil {
+		if isNotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
 		return err
 	}
 	mappings, err := readMongodbSupportAzMappings(meta)
@@ -607,6 +631,11 @@ func readMongodbInstanceCommon(d *schema.ResourceData, meta interface{}, r *sche
 	if cidrs != "" {
 		data["Cidrs"] = cidrs
 	}
+	//tags
+	err = mergeTagsData(d, &data, meta.(*KsyunClient), "mongodb")
+	if err != nil {
+		return err
+	}
 	//special
 	if _, ok := data["InstanceAccount"]; !ok {
 		err = d.Set("instance_account", "root")
@@ -626,16 +655,6 @@ func removeMongodbInstance(d *schema.ResourceData, meta interface{}) (err error)
 	return err
 }
 
-func canNotFoundMongodbError(err error) bool {
-	if ksyunError, ok := err.(awserr.RequestFailure); ok && ksyunError.StatusCode() == 404 {
-		return true
-	}
-	if strings.Contains(strings.ToLower(err.Error()), "not found") {
-		return true
-	}
-	return false
-}
-
 func mongodbShardInstanceSchemaDiffSuppressFunc() schema.SchemaDiffSuppressFunc {
 	return func(k, old, new string, d *schema.ResourceData) bool {
 		if old == "" {
@@ -717,6 +736,9 @@ func readMongodbShardInstanceNode(d *schema.ResourceData, meta interface{}) (dat
 	mongosNodeResult, shardNodeResult, err = readMongodbShardInstanceNodes(d, meta)
 	extra = make(map[string]SdkResponseMapping)
 	if err != nil {
+		if isNotFoundError(err) {
+			return data, extra, err
+		}
 		return data, extra, fmt.Errorf("read shard instance node error $%s ", err)
 	}
 	for _, v := range mongosNodeResult.([]interface{}) {
@@ -743,7 +765,7 @@ func readMongodbShardInstanceNode(d *schema.ResourceData, meta interface{}) (dat
 		}
 	}
 	if !exist {
-		return data, extra, fmt.Errorf("mongodb shard instance node %s not found", d.Get("node_id"))
+		return data, extra, newNotFoundError(fmt.Sprintf("mongodb shard instance node %s not found", d.Get("node_id")))
 	}
 	return data, extra, err
 }
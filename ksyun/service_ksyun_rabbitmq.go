@@ -106,7 +106,7 @@ func checkRabbitmqState(d *schema.ResourceData, meta interface{}, timeout time.D
 		Delay:      10 * time.Second,
 		MinTimeout: 1 * time.Minute,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = meta.(*KsyunClient).waitForState(stateConf)
 	return err
 }
 
@@ -187,6 +187,15 @@ func modifyRabbitmqInstanceNameAndProject(d *schema.ResourceData, meta interface
 	return err
 }
 
+func modifyRabbitmqInstanceTags(d *schema.ResourceData, meta interface{}, isUpdate bool) (err error) {
+	tagService := TagService{meta.(*KsyunClient)}
+	call, err := tagService.ReplaceResourcesTagsWithResourceFunc(d, resourceKsyunRabbitmq(), "rabbitmq", isUpdate)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCall([]ksyunApiCallFunc{call}, d, meta)
+}
+
 func modifyRabbitmqInstancePassword(d *schema.ResourceData, meta interface{}) (err error) {
 	transform := map[string]SdkReqTransform{
 		"instance_password": {},
@@ -328,6 +337,9 @@ func readRabbitmqInstance(d *schema.ResourceData, meta interface{}, id string) (
 	logger.Debug(logger.ReqFormat, action, queryReq)
 	resp, err = conn.DescribeInstance(&queryReq)
 	if err != nil {
+		if isNotFoundError(err) {
+			return data, err
+		}
 		return data, fmt.Errorf("error on reading instance %q, %s", d.Id(), err)
 	}
 	logger.Debug(logger.RespFormat, action, queryReq, *resp)
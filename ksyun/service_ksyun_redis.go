@@ -112,7 +112,7 @@ func checkRedisInstanceStatus(d *schema.ResourceData, meta interface{}, timeout
 		Delay:      20 * time.Second,
 		MinTimeout: 1 * time.Minute,
 	}
-	_, err = stateConf.WaitForState()
+	_, err = meta.(*KsyunClient).waitForState(stateConf)
 	return err
 }
 
@@ -233,10 +233,6 @@ func describeRedisInstance(d *schema.ResourceData, meta interface{}, id string)
 	return resp, err
 }
 
-func validateExists(err error) bool {
-	return strings.Contains(strings.ToLower(err.Error()), "cannot be found") || strings.Contains(strings.ToLower(err.Error()), "invalidaction")
-}
-
 func modifyRedisInstanceNameAndProject(d *schema.ResourceData, meta interface{}) error {
 	var (
 		err  error
@@ -456,6 +452,15 @@ func modifyRedisInstanceSg(d *schema.ResourceData, meta interface{}, isUpdate bo
 	return err
 }
 
+func modifyRedisInstanceTags(d *schema.ResourceData, meta interface{}, isUpdate bool) error {
+	tagService := TagService{meta.(*KsyunClient)}
+	call, err := tagService.ReplaceResourcesTagsWithResourceFunc(d, resourceRedisInstance(), "kcs", isUpdate)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCall([]ksyunApiCallFunc{call}, d, meta)
+}
+
 func modifyRedisInstanceAutoBackup(d *schema.ResourceData, meta interface{}) error {
 	var (
 		err  error
@@ -743,10 +748,6 @@ func redisSgAllocateFieldRespFunc(d *schema.ResourceData) FieldRespFunc {
 	}
 }
 
-func validateRedisSgExists(err error) bool {
-	return strings.Contains(strings.ToLower(err.Error()), "not exist")
-}
-
 func processRedisSecurityGroupRule(d *schema.ResourceData, meta interface{}, transform map[string]SdkReqTransform, isUpdate bool, sgId string) error {
 	var (
 		req    map[string]interface{}
@@ -914,6 +915,9 @@ func readRedisSecurityGroup(d *schema.ResourceData, meta interface{}, securityGr
 	action := "DescribeSecurityGroup"
 	resp, err = integrationAzConf.integrationRedisAz()
 	if err != nil {
+		if isNotFoundError(err) {
+			return resp, newNotFoundError(fmt.Sprintf("redis security group %s not exist", securityGroupId))
+		}
 		return resp, fmt.Errorf("error on reading redis security group %q, %s", d.Id(), err)
 	}
 	logger.Debug(logger.RespFormat, action, req, *resp)
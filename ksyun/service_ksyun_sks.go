@@ -61,7 +61,7 @@ func (s *SksService) ReadKey(d *schema.ResourceData, keyId string) (data map[str
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Key %s not exist ", keyId)
+		return data, newNotFoundError(fmt.Sprintf("Key %s not exist ", keyId))
 	}
 	return data, err
 }
@@ -240,7 +240,7 @@ func (s *SksService) RemoveKeyCall(d *schema.ResourceData) (callback ApiCall, er
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadKey(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading key when delete %q, %s", d.Id(), callErr))
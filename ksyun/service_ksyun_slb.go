@@ -63,7 +63,7 @@ func (s *SlbService) ReadLoadBalancer(d *schema.ResourceData, loadBalancerId str
 	if err != nil {
 		return data, err
 	}
-	results, err = s.ReadLoadBalancers(req)
+	results, err = s.client.batchDescribe("DescribeLoadBalancers", "LoadBalancerId", req, s.ReadLoadBalancers)
 	if err != nil {
 		return data, err
 	}
@@ -71,7 +71,7 @@ func (s *SlbService) ReadLoadBalancer(d *schema.ResourceData, loadBalancerId str
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("LoadBalancer %s not exist ", loadBalancerId)
+		return data, newNotFoundError(fmt.Sprintf("LoadBalancer %s not exist ", loadBalancerId))
 	}
 	return data, err
 }
@@ -155,7 +155,7 @@ func (s *SlbService) ReadAndSetLoadBalancer(d *schema.ResourceData, r *schema.Re
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading lb %q, %s", d.Id(), callErr))
@@ -211,7 +211,7 @@ func (s *SlbService) ReadAndSetLoadBalancers(d *schema.ResourceData, r *schema.R
 		idFiled:     "LoadBalancerId",
 		targetField: "lbs",
 		extra:       map[string]SdkResponseMapping{},
-	})
+	}, tagsMatchPlugin(s.client, "loadbalancer", "LoadBalancerId", data))
 }
 
 func (s *SlbService) CreateLoadBalancerCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
@@ -226,7 +226,8 @@ func (s *SlbService) CreateLoadBalancerCall(d *schema.ResourceData, r *schema.Re
 				}
 			},
 		},
-		"tags": {Ignore: true},
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
 		onlyTransform: false,
@@ -284,6 +285,7 @@ func (s *SlbService) ModifyLoadBalancerCall(d *schema.ResourceData, r *schema.Re
 	transform := map[string]SdkReqTransform{
 		"project_id":            {Ignore: true},
 		"tags":                  {Ignore: true},
+		"tags_all":              {Ignore: true},
 		"access_logs_enabled":   {Ignore: true},
 		"access_logs_s3_bucket": {Ignore: true},
 	}
@@ -409,7 +411,7 @@ func (s *SlbService) RemoveLoadBalancerCall(d *schema.ResourceData) (callback Ap
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadLoadBalancer(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading lb when delete %q, %s", d.Id(), callErr))
@@ -486,7 +488,7 @@ func (s *SlbService) ReadListener(d *schema.ResourceData, listenerId string) (da
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Lb listener %s not exist ", listenerId)
+		return data, newNotFoundError(fmt.Sprintf("Lb listener %s not exist ", listenerId))
 	}
 	return data, err
 }
@@ -800,7 +802,7 @@ func (s *SlbService) RemoveListenerCall(d *schema.ResourceData) (callback ApiCal
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadListener(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading health check when delete %q, %s", d.Id(), callErr))
@@ -877,7 +879,7 @@ func (s *SlbService) ReadLoadHealthCheck(d *schema.ResourceData, healthCheckId s
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("HealthCheck %s not exist ", healthCheckId)
+		return data, newNotFoundError(fmt.Sprintf("HealthCheck %s not exist ", healthCheckId))
 	}
 	return data, err
 }
@@ -1044,7 +1046,7 @@ func (s *SlbService) RemoveHealthCheckCall(d *schema.ResourceData) (callback Api
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadLoadHealthCheck(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading health check when delete %q, %s", d.Id(), callErr))
@@ -1121,7 +1123,7 @@ func (s *SlbService) ReadLbRule(d *schema.ResourceData, lbRuleId string) (data m
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Lb Rule %s not exist ", lbRuleId)
+		return data, newNotFoundError(fmt.Sprintf("Lb Rule %s not exist ", lbRuleId))
 	}
 	return data, err
 }
@@ -1306,7 +1308,7 @@ func (s *SlbService) RemoveLbRuleCall(d *schema.ResourceData) (callback ApiCall,
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadLbRule(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading lb rule when delete %q, %s", d.Id(), callErr))
@@ -1383,7 +1385,7 @@ func (s *SlbService) ReadHostHeader(d *schema.ResourceData, hostHeaderId string)
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Host header %s not exist ", hostHeaderId)
+		return data, newNotFoundError(fmt.Sprintf("Host header %s not exist ", hostHeaderId))
 	}
 	return data, err
 }
@@ -1535,7 +1537,7 @@ func (s *SlbService) RemoveHostHeaderCall(d *schema.ResourceData) (callback ApiC
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadHostHeader(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading host header when delete %q, %s", d.Id(), callErr))
@@ -1615,7 +1617,7 @@ func (s *SlbService) ReadLoadBalancerAcl(d *schema.ResourceData, loadBalancerAcl
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("LoadBalancerAcls %s not exist ", loadBalancerAclId)
+		return data, newNotFoundError(fmt.Sprintf("LoadBalancerAcls %s not exist ", loadBalancerAclId))
 	}
 	return data, err
 }
@@ -1637,7 +1639,7 @@ func (s *SlbService) ReadLoadBalancerAclEntry(d *schema.ResourceData, loadBalanc
 		}
 	}
 	if !found {
-		return data, fmt.Errorf("LoadBalancerAclEntry not exist")
+		return data, newNotFoundError("LoadBalancerAclEntry not exist")
 	}
 	return data, err
 }
@@ -1657,12 +1659,12 @@ func (s *SlbService) ReadLoadBalancerAclAssociate(listenerId string, loadBalance
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf(" LoadBalancerAclAssociate listener_id [%s] and load_balancer_acl_id [%s] not exist ",
-			listenerId, loadBalancerAclId)
+		return data, newNotFoundError(fmt.Sprintf(" LoadBalancerAclAssociate listener_id [%s] and load_balancer_acl_id [%s] not exist ",
+			listenerId, loadBalancerAclId))
 	}
 	if _, ok := data["LoadBalancerAclId"]; !ok || data["LoadBalancerAclId"] != loadBalancerAclId {
-		return data, fmt.Errorf(" LoadBalancerAclAssociate listener_id [%s] and load_balancer_acl_id [%s] not exist ",
-			listenerId, loadBalancerAclId)
+		return data, newNotFoundError(fmt.Sprintf(" LoadBalancerAclAssociate listener_id [%s] and load_balancer_acl_id [%s] not exist ",
+			listenerId, loadBalancerAclId))
 	}
 	return data, err
 }
@@ -2088,7 +2090,7 @@ func (s *SlbService) RemoveLoadBalancerAclCall(d *schema.ResourceData) (callback
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadLoadBalancerAcl(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading lb acl when delete %q, %s", d.Id(), callErr))
@@ -2127,7 +2129,7 @@ func (s *SlbService) RemoveLoadBalancerAclEntryCommonCall(aclId string, entryId
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				data, callErr := s.ReadLoadBalancerAcl(d, aclId)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading lb rulr entry when delete %q, %s", d.Id(), callErr))
@@ -2180,7 +2182,7 @@ func (s *SlbService) RemoveLoadBalancerAclAssociateCommonCall(listenerId string,
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadLoadBalancerAclAssociate(listenerId, loadBalancerAclId)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading lb acl when delete %q, %s", d.Id(), callErr))
@@ -2273,7 +2275,7 @@ func (s *SlbService) ReadRealServer(d *schema.ResourceData, registerId string) (
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Real Server %s not exist ", registerId)
+		return data, newNotFoundError(fmt.Sprintf("Real Server %s not exist ", registerId))
 	}
 	return data, err
 }
@@ -2422,7 +2424,7 @@ func (s *SlbService) RemoveRealServerCall(d *schema.ResourceData) (callback ApiC
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadRealServer(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading real server when delete %q, %s", d.Id(), callErr))
@@ -2499,7 +2501,7 @@ func (s *SlbService) ReadBackendServerGroup(d *schema.ResourceData, backendServe
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("BackendServerGroup %s not exist ", backendServerGroupId)
+		return data, newNotFoundError(fmt.Sprintf("BackendServerGroup %s not exist ", backendServerGroupId))
 	}
 	return data, err
 }
@@ -2696,7 +2698,7 @@ func (s *SlbService) RemoveBackendServerGroupCall(d *schema.ResourceData) (callb
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadBackendServerGroup(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading backend server group when delete %q, %s", d.Id(), callErr))
@@ -2773,7 +2775,7 @@ func (s *SlbService) ReadBackendServer(d *schema.ResourceData, registerId string
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("BackendServer %s not exist ", registerId)
+		return data, newNotFoundError(fmt.Sprintf("BackendServer %s not exist ", registerId))
 	}
 	return data, err
 }
@@ -2904,7 +2906,7 @@ func (s *SlbService) RemoveBackendServerCall(d *schema.ResourceData) (callback A
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadBackendServer(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading backend server when delete %q, %s", d.Id(), callErr))
@@ -0,0 +1,540 @@
+package ksyun
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"time"
+)
+
+// start snapshot
+
+func (s *EbsService) ReadSnapshots(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	conn := s.client.ebsconn
+	action := "DescribeSnapshots"
+	logger.Debug(logger.ReqFormat, action, condition)
+	resp, err = conn.DescribeSnapshots(&condition)
+	if err != nil {
+		return data, err
+	}
+	results, err = getSdkValue("Snapshots", *resp)
+	if err != nil || results == nil {
+		return data, err
+	}
+	data = results.([]interface{})
+	return data, err
+}
+
+func (s *EbsService) ReadSnapshot(d *schema.ResourceData, snapshotId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if snapshotId == "" {
+		snapshotId = d.Id()
+	}
+	req := map[string]interface{}{
+		"SnapshotId": snapshotId,
+	}
+	results, err = s.ReadSnapshots(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		if item, ok := v.(map[string]interface{}); ok && item["SnapshotId"] == snapshotId {
+			data = item
+		}
+	}
+	if len(data) == 0 {
+		return data, newNotFoundError(fmt.Sprintf("Snapshot %s not exist ", snapshotId))
+	}
+	return data, err
+}
+
+func (s *EbsService) snapshotStateRefreshFunc(d *schema.ResourceData, snapshotId string, failStates []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		data, err := s.ReadSnapshot(d, snapshotId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		status, err := getSdkValue("SnapshotStatus", data)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, v := range failStates {
+			if v == status.(string) {
+				return nil, "", fmt.Errorf("snapshot status  error, status:%v", status)
+			}
+		}
+		return data, status.(string), nil
+	}
+}
+
+func (s *EbsService) checkSnapshotState(d *schema.ResourceData, snapshotId string, target []string, timeout time.Duration) (state interface{}, err error) {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{},
+		Target:       target,
+		Refresh:      s.snapshotStateRefreshFunc(d, snapshotId, []string{"error"}),
+		Timeout:      timeout,
+		PollInterval: 5 * time.Second,
+		Delay:        10 * time.Second,
+		MinTimeout:   1 * time.Second,
+	}
+	return s.client.waitForState(stateConf)
+}
+
+func (s *EbsService) ReadAndSetSnapshot(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadSnapshot(d, "")
+	if err != nil {
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return err
+}
+
+func (s *EbsService) ReadAndSetSnapshots(d *schema.ResourceData, r *schema.Resource) (err error) {
+	transform := map[string]SdkReqTransform{
+		"ids": {Ignore: true},
+	}
+	req, err := mergeDataSourcesReq(d, r, transform)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	if v, ok := d.GetOk("ids"); ok {
+		ids = SchemaSetToStringSlice(v)
+	}
+	if len(ids) == 1 {
+		req["SnapshotId"] = ids[0]
+	}
+	data, err := s.ReadSnapshots(req)
+	if err != nil {
+		return err
+	}
+
+	return mergeDataSourcesResp(d, r, ksyunDataSource{
+		collection:  data,
+		nameField:   "SnapshotName",
+		idFiled:     "SnapshotId",
+		targetField: "snapshots",
+		extra: map[string]SdkResponseMapping{
+			"SnapshotId": {
+				Field:    "id",
+				KeepAuto: true,
+			},
+		},
+	}, func(data *schema.ResourceData, m map[string]interface{}) (result map[string]interface{}, flag bool, err error) {
+		if len(ids) > 1 {
+			flag = true
+			for _, id := range ids {
+				if m["SnapshotId"] == id {
+					result = m
+				}
+			}
+		}
+		return result, flag, err
+	})
+}
+
+func (s *EbsService) CreateSnapshotCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, false, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateSnapshot",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.ebsconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.CreateSnapshot(call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("SnapshotId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			_, err = s.checkSnapshotState(d, "", []string{"available"}, d.Timeout(schema.TimeoutCreate))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *EbsService) CreateSnapshot(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateSnapshotCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *EbsService) ModifySnapshotCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, true, nil, nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if len(req) > 0 {
+		req["SnapshotId"] = d.Id()
+		callback = ApiCall{
+			param:  &req,
+			action: "ModifySnapshot",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.ebsconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = conn.ModifySnapshot(call.param)
+				return resp, err
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+				return err
+			},
+		}
+	}
+	return callback, err
+}
+
+func (s *EbsService) ModifySnapshot(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.ModifySnapshotCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *EbsService) RemoveSnapshotCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"SnapshotId": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteSnapshot",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.ebsconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = conn.DeleteSnapshot(call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+				_, callErr := s.ReadSnapshot(d, "")
+				if callErr != nil {
+					if isNotFoundError(callErr) {
+						return nil
+					} else {
+						return resource.NonRetryableError(fmt.Errorf("error on  reading snapshot when delete %q, %s", d.Id(), callErr))
+					}
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *EbsService) RemoveSnapshot(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveSnapshotCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+// start auto snapshot policy
+// the auto snapshot policy actions are not generated in the ksc sdk, they are called by callSdkAction
+
+func (s *EbsService) ReadAutoSnapshotPolicies(condition map[string]interface{}) (data []interface{}, err error) {
+	var (
+		resp    *map[string]interface{}
+		results interface{}
+	)
+	conn := s.client.ebsconn
+	action := "DescribeAutoSnapshotPolicy"
+	logger.Debug(logger.ReqFormat, action, condition)
+	resp, err = callSdkAction(conn.Client, action, &condition)
+	if err != nil {
+		return data, err
+	}
+	results, err = getSdkValue("AutoSnapshotPolicySet", *resp)
+	if err != nil || results == nil {
+		return data, err
+	}
+	data = results.([]interface{})
+	return data, err
+}
+
+func (s *EbsService) ReadAutoSnapshotPolicy(d *schema.ResourceData, policyId string) (data map[string]interface{}, err error) {
+	var (
+		results []interface{}
+	)
+	if policyId == "" {
+		policyId = d.Id()
+	}
+	req := map[string]interface{}{
+		"AutoSnapshotPolicyId.1": policyId,
+	}
+	results, err = s.ReadAutoSnapshotPolicies(req)
+	if err != nil {
+		return data, err
+	}
+	for _, v := range results {
+		if item, ok := v.(map[string]interface{}); ok && item["AutoSnapshotPolicyId"] == policyId {
+			data = item
+		}
+	}
+	if len(data) == 0 {
+		return data, newNotFoundError(fmt.Sprintf("AutoSnapshotPolicy %s not exist ", policyId))
+	}
+	return data, err
+}
+
+func (s *EbsService) ReadAndSetAutoSnapshotPolicy(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadAutoSnapshotPolicy(d, "")
+	if err != nil {
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return err
+}
+
+func autoSnapshotPolicyTransform() map[string]SdkReqTransform {
+	return map[string]SdkReqTransform{
+		"auto_snapshot_date": {
+			mapping: "AutoSnapshotDate",
+			Type:    TransformWithN,
+		},
+		"auto_snapshot_time": {
+			mapping: "AutoSnapshotTime",
+			Type:    TransformWithN,
+		},
+	}
+}
+
+func (s *EbsService) CreateAutoSnapshotPolicyCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, false, autoSnapshotPolicyTransform(), nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "CreateAutoSnapshotPolicy",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.ebsconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = callSdkAction(conn.Client, call.action, call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			id, err := getSdkValue("AutoSnapshotPolicyId", *resp)
+			if err != nil {
+				return err
+			}
+			d.SetId(id.(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *EbsService) CreateAutoSnapshotPolicy(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.CreateAutoSnapshotPolicyCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *EbsService) ModifyAutoSnapshotPolicyCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
+	req, err := SdkRequestAutoMapping(d, r, true, autoSnapshotPolicyTransform(), nil, SdkReqParameter{
+		onlyTransform: false,
+	})
+	if err != nil {
+		return callback, err
+	}
+	if len(req) > 0 {
+		req["AutoSnapshotPolicyId"] = d.Id()
+		callback = ApiCall{
+			param:  &req,
+			action: "ModifyAutoSnapshotPolicy",
+			executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+				conn := client.ebsconn
+				logger.Debug(logger.RespFormat, call.action, *(call.param))
+				resp, err = callSdkAction(conn.Client, call.action, call.param)
+				return resp, err
+			},
+			afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+				logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+				return err
+			},
+		}
+	}
+	return callback, err
+}
+
+func (s *EbsService) ModifyAutoSnapshotPolicy(d *schema.ResourceData, r *schema.Resource) (err error) {
+	call, err := s.ModifyAutoSnapshotPolicyCall(d, r)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *EbsService) RemoveAutoSnapshotPolicyCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"AutoSnapshotPolicyId.1": d.Id(),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "DeleteAutoSnapshotPolicy",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.ebsconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = callSdkAction(conn.Client, call.action, call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			return resource.Retry(5*time.Minute, func() *resource.RetryError {
+				_, callErr := s.ReadAutoSnapshotPolicy(d, "")
+				if callErr != nil {
+					if isNotFoundError(callErr) {
+						return nil
+					} else {
+						return resource.NonRetryableError(fmt.Errorf("error on  reading auto snapshot policy when delete %q, %s", d.Id(), callErr))
+					}
+				}
+				_, callErr = call.executeCall(d, client, call)
+				if callErr == nil {
+					return nil
+				}
+				return resource.RetryableError(callErr)
+			})
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *EbsService) RemoveAutoSnapshotPolicy(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveAutoSnapshotPolicyCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+// start auto snapshot policy attachment
+
+func (s *EbsService) ReadAutoSnapshotPolicyAttachment(d *schema.ResourceData, policyId string, volumeId string) (data map[string]interface{}, err error) {
+	data, err = s.ReadVolume(d, volumeId, true)
+	if err != nil {
+		return data, err
+	}
+	if id, ok := data["AutoSnapshotPolicyId"]; !ok || id != policyId {
+		return data, newNotFoundError(fmt.Sprintf("AutoSnapshotPolicy %s not apply to Volume %s ", policyId, volumeId))
+	}
+	return data, err
+}
+
+func (s *EbsService) ReadAndSetAutoSnapshotPolicyAttachment(d *schema.ResourceData, r *schema.Resource) (err error) {
+	data, err := s.ReadAutoSnapshotPolicyAttachment(d, d.Get("auto_snapshot_policy_id").(string), d.Get("volume_id").(string))
+	if err != nil {
+		return err
+	}
+	SdkResponseAutoResourceData(d, r, data, nil)
+	return err
+}
+
+func (s *EbsService) CreateAutoSnapshotPolicyAttachmentCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	req := map[string]interface{}{
+		"AutoSnapshotPolicyId": d.Get("auto_snapshot_policy_id"),
+		"VolumeId.1":           d.Get("volume_id"),
+	}
+	callback = ApiCall{
+		param:  &req,
+		action: "ApplyAutoSnapshotPolicy",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.ebsconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = callSdkAction(conn.Client, call.action, call.param)
+			return resp, err
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			d.SetId(d.Get("auto_snapshot_policy_id").(string) + ":" + d.Get("volume_id").(string))
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *EbsService) CreateAutoSnapshotPolicyAttachment(d *schema.ResourceData) (err error) {
+	call, err := s.CreateAutoSnapshotPolicyAttachmentCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
+
+func (s *EbsService) RemoveAutoSnapshotPolicyAttachmentCall(d *schema.ResourceData) (callback ApiCall, err error) {
+	removeReq := map[string]interface{}{
+		"AutoSnapshotPolicyId": d.Get("auto_snapshot_policy_id"),
+		"VolumeId.1":           d.Get("volume_id"),
+	}
+	callback = ApiCall{
+		param:  &removeReq,
+		action: "CancelAutoSnapshotPolicy",
+		executeCall: func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (resp *map[string]interface{}, err error) {
+			conn := client.ebsconn
+			logger.Debug(logger.RespFormat, call.action, *(call.param))
+			resp, err = callSdkAction(conn.Client, call.action, call.param)
+			return resp, err
+		},
+		callError: func(d *schema.ResourceData, client *KsyunClient, call ApiCall, baseErr error) error {
+			_, callErr := s.ReadAutoSnapshotPolicyAttachment(d, d.Get("auto_snapshot_policy_id").(string), d.Get("volume_id").(string))
+			if callErr != nil && isNotFoundError(callErr) {
+				return nil
+			}
+			return baseErr
+		},
+		afterCall: func(d *schema.ResourceData, client *KsyunClient, resp *map[string]interface{}, call ApiCall) (err error) {
+			logger.Debug(logger.RespFormat, call.action, *(call.param), *resp)
+			return err
+		},
+	}
+	return callback, err
+}
+
+func (s *EbsService) RemoveAutoSnapshotPolicyAttachment(d *schema.ResourceData) (err error) {
+	call, err := s.RemoveAutoSnapshotPolicyAttachmentCall(d)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+}
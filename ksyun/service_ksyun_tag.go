@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"sort"
 	"strconv"
 )
 
@@ -148,7 +149,7 @@ func (s *TagService) ReadTagByTagValue(d *schema.ResourceData, tagKey string, ta
 		return data, err
 	}
 	if len(results) == 0 {
-		return data, fmt.Errorf("tagKey %s not exist ", tagKey)
+		return data, newNotFoundError(fmt.Sprintf("tagKey %s not exist ", tagKey))
 	}
 	var findValue bool
 	for _, v := range results {
@@ -159,7 +160,7 @@ func (s *TagService) ReadTagByTagValue(d *schema.ResourceData, tagKey string, ta
 		}
 	}
 	if !findValue {
-		return nil, fmt.Errorf("tagValue %s not exist ", tagKey)
+		return nil, newNotFoundError(fmt.Sprintf("tagValue %s not exist ", tagKey))
 	}
 	return data, err
 }
@@ -248,13 +249,28 @@ func (s *TagService) ReplaceResourcesTagsCall(d *schema.ResourceData, r *schema.
 }
 
 func (s *TagService) ReplaceResourcesTagsWithResourceCall(d *schema.ResourceData, r *schema.Resource, resourceType string, isUpdate bool, disableDryRun bool) (callback ApiCall, err error) {
+	// tags_all is the effective tags when resource supports provider default_tags
+	tagsField := "tags"
+	if _, ok := r.Schema["tags_all"]; ok {
+		tagsField = "tags_all"
+	}
+	ignoreTags := s.client.ignoreTags
 	transform := map[string]SdkReqTransform{
-		"tags": {
+		tagsField: {
 			FieldReqFunc: func(i interface{}, s string, m map[string]string, i2 int, s2 string, m2 *map[string]interface{}) (int, error) {
 				if tagMap, ok := i.(map[string]interface{}); ok {
-					for k, v := range tagMap {
+					// the tags are sent in the order of keys, so the same tags always make the same request
+					var keys []string
+					for k := range tagMap {
+						keys = append(keys, k)
+					}
+					sort.Strings(keys)
+					for _, k := range keys {
+						if ignoreTags.ignored(k) {
+							continue
+						}
 						(*m2)["Tag_"+strconv.Itoa(i2)+"_Key"] = k
-						(*m2)["Tag_"+strconv.Itoa(i2)+"_Value"] = v
+						(*m2)["Tag_"+strconv.Itoa(i2)+"_Value"] = tagMap[k]
 						i2++
 					}
 				}
@@ -268,7 +284,52 @@ func (s *TagService) ReplaceResourcesTagsWithResourceCall(d *schema.ResourceData
 	}
 	if len(req) > 0 {
 		req["ResourceType"] = resourceType
-		return s.ReplaceResourcesTagsCommonCall(req, disableDryRun)
+		callback, err = s.ReplaceResourcesTagsCommonCall(req, disableDryRun)
+		if isUpdate {
+			callback.beforeCall = s.keepIgnoredTagsBeforeCall(resourceType)
+		}
 	}
 	return callback, err
 }
+
+// keepIgnoredTagsBeforeCall appends the current ignored tags of resource to the replace request,
+// so the tags managed outside of terraform are left untouched
+func (s *TagService) keepIgnoredTagsBeforeCall(resourceType string) beforeCallFunc {
+	return func(d *schema.ResourceData, client *KsyunClient, call ApiCall) (bool, error) {
+		if client.ignoreTags.empty() || d.Id() == "" {
+			return true, nil
+		}
+		tags, err := s.ReadTagByResourceId(d, d.Id(), resourceType)
+		if err != nil {
+			return false, err
+		}
+		index := 0
+		for {
+			if _, ok := (*call.param)["Tag_"+strconv.Itoa(index)+"_Key"]; !ok {
+				break
+			}
+			index++
+		}
+		for _, tag := range tags {
+			m := tag.(map[string]interface{})
+			if key, ok := m["TagKey"].(string); ok && client.ignoreTags.ignored(key) {
+				(*call.param)["Tag_"+strconv.Itoa(index)+"_Key"] = key
+				(*call.param)["Tag_"+strconv.Itoa(index)+"_Value"] = m["TagValue"]
+				index++
+			}
+		}
+		return true, nil
+	}
+}
+
+// ReplaceResourcesTagsWithResourceFunc wraps the replace tags call for the resources still using ksyunApiCallFunc
+func (s *TagService) ReplaceResourcesTagsWithResourceFunc(d *schema.ResourceData, r *schema.Resource, resourceType string, isUpdate bool) (call ksyunApiCallFunc, err error) {
+	tagCall, err := s.ReplaceResourcesTagsWithResourceCall(d, r, resourceType, isUpdate, false)
+	if err != nil || tagCall.executeCall == nil {
+		return call, err
+	}
+	call = func(d *schema.ResourceData, meta interface{}) error {
+		return ksyunApiCallNew([]ApiCall{tagCall}, d, meta.(*KsyunClient), false)
+	}
+	return call, err
+}
@@ -14,32 +14,25 @@ type EbsService struct {
 }
 
 func (s *EbsService) ReadVolumes(condition map[string]interface{}) (data []interface{}, err error) {
-	var (
-		resp    *map[string]interface{}
-		results interface{}
-	)
-	return pageQuery(condition, "MaxResults", "Marker", 50, 0, func(condition map[string]interface{}) ([]interface{}, error) {
+	return s.ReadVolumesPage(condition, 0)
+}
+
+// ReadVolumesPage lists at most maxResults volumes, 0 means all the volumes
+func (s *EbsService) ReadVolumesPage(condition map[string]interface{}, maxResults int) (data []interface{}, err error) {
+	return pageQueryResponse(condition, pageResponseOptions{
+		pageOptions: pageOptions{
+			limitParam: "MaxResults",
+			pageParam:  "Marker",
+			limit:      50,
+			maxResults: maxResults,
+		},
+		itemsField: "Volumes",
+		totalField: "TotalCount",
+	}, func(condition map[string]interface{}) (*map[string]interface{}, error) {
 		conn := s.client.ebsconn
 		action := "DescribeVolumes"
 		logger.Debug(logger.ReqFormat, action, condition)
-		if condition == nil {
-			resp, err = conn.DescribeVolumes(nil)
-			if err != nil {
-				return data, err
-			}
-		} else {
-			resp, err = conn.DescribeVolumes(&condition)
-			if err != nil {
-				return data, err
-			}
-		}
-
-		results, err = getSdkValue("Volumes", *resp)
-		if err != nil {
-			return data, err
-		}
-		data = results.([]interface{})
-		return data, err
+		return conn.DescribeVolumes(&condition)
 	})
 }
 
@@ -65,7 +58,7 @@ func (s *EbsService) ReadVolume(d *schema.ResourceData, volumeId string, allProj
 		}
 	}
 
-	results, err = s.ReadVolumes(req)
+	results, err = s.client.batchDescribe("DescribeVolumes", "VolumeId", req, s.ReadVolumes)
 	if err != nil {
 		return data, err
 	}
@@ -73,7 +66,7 @@ func (s *EbsService) ReadVolume(d *schema.ResourceData, volumeId string, allProj
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Volume %s not exist ", volumeId)
+		return data, newNotFoundError(fmt.Sprintf("Volume %s not exist ", volumeId))
 	}
 	return data, err
 }
@@ -111,7 +104,7 @@ func (s *EbsService) checkVolumeState(d *schema.ResourceData, volumeId string, t
 		Delay:      10 * time.Second,
 		MinTimeout: 1 * time.Minute,
 	}
-	return stateConf.WaitForState()
+	return s.client.waitForState(stateConf)
 }
 
 func (s *EbsService) ReadAndSetVolume(d *schema.ResourceData, r *schema.Resource) (err error) {
@@ -121,12 +114,16 @@ func (s *EbsService) ReadAndSetVolume(d *schema.ResourceData, r *schema.Resource
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading volume %q, %s", d.Id(), callErr))
 			}
 		} else {
+			err = mergeTagsData(d, &data, s.client, "volume")
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
 			SdkResponseAutoResourceData(d, r, data, chargeExtraForVpc(data))
 			return nil
 		}
@@ -144,7 +141,7 @@ func (s *EbsService) ReadAndSetVolumes(d *schema.ResourceData, r *schema.Resourc
 	if err != nil {
 		return err
 	}
-	data, err := s.ReadVolumes(req)
+	data, err := s.ReadVolumesPage(req, dataSourcePageLimit(d))
 	if err != nil {
 		return err
 	}
@@ -154,12 +151,14 @@ func (s *EbsService) ReadAndSetVolumes(d *schema.ResourceData, r *schema.Resourc
 		idFiled:     "VolumeId",
 		targetField: "volumes",
 		extra:       map[string]SdkResponseMapping{},
-	})
+	}, tagsMatchPlugin(s.client, "volume", "VolumeId", data))
 }
 
 func (s *EbsService) CreateVolumeCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
 	transform := map[string]SdkReqTransform{
 		"online_resize": {Ignore: true},
+		"tags":          {Ignore: true},
+		"tags_all":      {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{
 		onlyTransform: false,
@@ -198,7 +197,12 @@ func (s *EbsService) CreateVolume(d *schema.ResourceData, r *schema.Resource) (e
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "volume", false, true)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, tagCall}, d, s.client, true)
 }
 
 func (s *EbsService) ModifyVolumeProjectCall(d *schema.ResourceData, resource *schema.Resource) (callback ApiCall, err error) {
@@ -228,6 +232,8 @@ func (s *EbsService) ModifyVolumeInfoCall(d *schema.ResourceData, r *schema.Reso
 		"project_id":    {Ignore: true},
 		"size":          {Ignore: true},
 		"online_resize": {Ignore: true},
+		"tags":          {Ignore: true},
+		"tags_all":      {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{
 		false,
@@ -322,7 +328,12 @@ func (s *EbsService) ModifyVolume(d *schema.ResourceData, r *schema.Resource) (e
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{projectCall, infoCall, call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "volume", true, false)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{projectCall, infoCall, call, tagCall}, d, s.client, true)
 }
 
 func (s *EbsService) RemoveVolumeCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -343,7 +354,7 @@ func (s *EbsService) RemoveVolumeCall(d *schema.ResourceData) (callback ApiCall,
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadVolume(d, "", false)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading volume when delete %q, %s", d.Id(), callErr))
@@ -381,7 +392,7 @@ func (s *EbsService) ReadVolumeAttach(d *schema.ResourceData, volumeId string, i
 			return data, fmt.Errorf("InstanceId %s not attach in Volume %s ", instanceId, volumeId)
 		}
 	} else {
-		return data, fmt.Errorf("InstanceId %s not associate in Address %s ", instanceId, volumeId)
+		return data, newNotFoundError(fmt.Sprintf("InstanceId %s not associate in Address %s ", instanceId, volumeId))
 	}
 	flag, err := getSdkValue("Attachment.0.DeleteWithInstance", data)
 	if err != nil {
@@ -507,7 +518,7 @@ func (s *EbsService) RemoveVolumeAttachCall(d *schema.ResourceData) (callback Ap
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadVolumeAttach(d, d.Get("volume_id").(string), d.Get("instance_id").(string))
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading volume attach when delete %q, %s", d.Id(), callErr))
@@ -59,7 +59,7 @@ func (s *VpcService) ReadNetworkInterface(d *schema.ResourceData, instanceId str
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("NetworkInterface %s not exist ", instanceId)
+		return data, newNotFoundError(fmt.Sprintf("NetworkInterface %s not exist ", instanceId))
 	}
 	return data, err
 }
@@ -147,7 +147,7 @@ func (s *VpcService) RemoveNetworkInterfaceCall(d *schema.ResourceData) (callbac
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadNetworkInterface(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading network interface when delete %q, %s", d.Id(), callErr))
@@ -215,31 +215,27 @@ func (s *VpcService) ModifyNetworkInterfaceCall(updateReq *map[string]interface{
 }
 
 func (s *VpcService) ReadVpcs(condition map[string]interface{}) (data []interface{}, err error) {
-	var (
-		resp    *map[string]interface{}
-		results interface{}
-	)
-	conn := s.client.vpcconn
-	action := "DescribeVpcs"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeVpcs(nil)
-		if err != nil {
-			return data, err
-		}
-	} else {
-		resp, err = conn.DescribeVpcs(&condition)
-		if err != nil {
-			return data, err
-		}
-	}
-
-	results, err = getSdkValue("VpcSet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+	return s.ReadVpcsPage(condition, 0)
+}
+
+// ReadVpcsPage lists at most maxResults vpcs, 0 means all the vpcs
+func (s *VpcService) ReadVpcsPage(condition map[string]interface{}, maxResults int) (data []interface{}, err error) {
+	return pageQueryResponse(condition, pageResponseOptions{
+		pageOptions: pageOptions{
+			limitParam: "MaxResults",
+			pageParam:  "NextToken",
+			limit:      1000,
+			byToken:    true,
+			maxResults: maxResults,
+		},
+		itemsField: "VpcSet",
+		tokenField: "NextToken",
+	}, func(condition map[string]interface{}) (*map[string]interface{}, error) {
+		conn := s.client.vpcconn
+		action := "DescribeVpcs"
+		logger.Debug(logger.ReqFormat, action, condition)
+		return conn.DescribeVpcs(&condition)
+	})
 }
 
 func (s *VpcService) ReadVpc(d *schema.ResourceData, vpcId string) (data map[string]interface{}, err error) {
@@ -252,7 +248,7 @@ func (s *VpcService) ReadVpc(d *schema.ResourceData, vpcId string) (data map[str
 	req := map[string]interface{}{
 		"VpcId.1": vpcId,
 	}
-	results, err = s.ReadVpcs(req)
+	results, err = s.client.batchDescribe("DescribeVpcs", "VpcId", req, s.ReadVpcs)
 	if err != nil {
 		return data, err
 	}
@@ -260,7 +256,7 @@ func (s *VpcService) ReadVpc(d *schema.ResourceData, vpcId string) (data map[str
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Vpc %s not exist ", vpcId)
+		return data, newNotFoundError(fmt.Sprintf("Vpc %s not exist ", vpcId))
 	}
 	return data, err
 }
@@ -270,6 +266,10 @@ func (s *VpcService) ReadAndSetVpc(d *schema.ResourceData, r *schema.Resource) (
 	if err != nil {
 		return err
 	}
+	err = mergeTagsData(d, &data, s.client, "vpc")
+	if err != nil {
+		return err
+	}
 	SdkResponseAutoResourceData(d, r, data, nil)
 	return err
 }
@@ -285,7 +285,7 @@ func (s *VpcService) ReadAndSetVpcs(d *schema.ResourceData, r *schema.Resource)
 	if err != nil {
 		return err
 	}
-	data, err := s.ReadVpcs(req)
+	data, err := s.ReadVpcsPage(req, dataSourcePageLimit(d))
 	if err != nil {
 		return err
 	}
@@ -305,11 +305,15 @@ func (s *VpcService) ReadAndSetVpcs(d *schema.ResourceData, r *schema.Resource)
 				KeepAuto: true,
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "vpc", "VpcId", data))
 }
 
 func (s *VpcService) CreateVpcCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, false, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{false})
 	if err != nil {
 		return callback, err
 	}
@@ -340,11 +344,20 @@ func (s *VpcService) CreateVpc(d *schema.ResourceData, r *schema.Resource) (err
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "vpc", false, true)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, tagCall}, d, s.client, true)
 }
 
 func (s *VpcService) ModifyVpcCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, true, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{false})
 	if err != nil {
 		return callback, err
 	}
@@ -373,7 +386,12 @@ func (s *VpcService) ModifyVpc(d *schema.ResourceData, r *schema.Resource) (err
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "vpc", true, false)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, tagCall}, d, s.client, true)
 }
 
 func (s *VpcService) RemoveVpcCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -393,7 +411,7 @@ func (s *VpcService) RemoveVpcCall(d *schema.ResourceData) (callback ApiCall, er
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadVpc(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading vpc when delete %q, %s", d.Id(), callErr))
@@ -423,31 +441,27 @@ func (s *VpcService) RemoveVpc(d *schema.ResourceData) (err error) {
 }
 
 func (s *VpcService) ReadSubnets(condition map[string]interface{}) (data []interface{}, err error) {
-	var (
-		resp    *map[string]interface{}
-		results interface{}
-	)
-	conn := s.client.vpcconn
-	action := "DescribeSubnets"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeSubnets(nil)
-		if err != nil {
-			return data, err
-		}
-	} else {
-		resp, err = conn.DescribeSubnets(&condition)
-		if err != nil {
-			return data, err
-		}
-	}
-	logger.Debug(logger.ReqFormat, action, *resp)
-	results, err = getSdkValue("SubnetSet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+	return s.ReadSubnetsPage(condition, 0)
+}
+
+// ReadSubnetsPage lists at most maxResults subnets, 0 means all the subnets
+func (s *VpcService) ReadSubnetsPage(condition map[string]interface{}, maxResults int) (data []interface{}, err error) {
+	return pageQueryResponse(condition, pageResponseOptions{
+		pageOptions: pageOptions{
+			limitParam: "MaxResults",
+			pageParam:  "NextToken",
+			limit:      1000,
+			byToken:    true,
+			maxResults: maxResults,
+		},
+		itemsField: "SubnetSet",
+		tokenField: "NextToken",
+	}, func(condition map[string]interface{}) (*map[string]interface{}, error) {
+		conn := s.client.vpcconn
+		action := "DescribeSubnets"
+		logger.Debug(logger.ReqFormat, action, condition)
+		return conn.DescribeSubnets(&condition)
+	})
 }
 
 func (s *VpcService) ReadSubnet(d *schema.ResourceData, subnetId string) (data map[string]interface{}, err error) {
@@ -460,7 +474,7 @@ func (s *VpcService) ReadSubnet(d *schema.ResourceData, subnetId string) (data m
 	req := map[string]interface{}{
 		"SubnetId.1": subnetId,
 	}
-	results, err = s.ReadSubnets(req)
+	results, err = s.client.batchDescribe("DescribeSubnets", "SubnetId", req, s.ReadSubnets)
 	if err != nil {
 		return data, err
 	}
@@ -474,7 +488,7 @@ func (s *VpcService) ReadSubnet(d *schema.ResourceData, subnetId string) (data m
 		}
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Subnet %s not exist ", subnetId)
+		return data, newNotFoundError(fmt.Sprintf("Subnet %s not exist ", subnetId))
 	}
 	return data, err
 }
@@ -484,6 +498,10 @@ func (s *VpcService) ReadAndSetSubnet(d *schema.ResourceData, r *schema.Resource
 	if err != nil {
 		return err
 	}
+	err = mergeTagsData(d, &data, s.client, "subnet")
+	if err != nil {
+		return err
+	}
 	extra := map[string]SdkResponseMapping{
 		"AvailableIpNumber": {Field: "available_ip_number"},
 	}
@@ -523,7 +541,7 @@ func (s *VpcService) ReadAndSetSubnets(d *schema.ResourceData, r *schema.Resourc
 	if err != nil {
 		return err
 	}
-	data, err := s.ReadSubnets(req)
+	data, err := s.ReadSubnetsPage(req, dataSourcePageLimit(d))
 	if err != nil {
 		return err
 	}
@@ -542,7 +560,7 @@ func (s *VpcService) ReadAndSetSubnets(d *schema.ResourceData, r *schema.Resourc
 				KeepAuto: true,
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "subnet", "SubnetId", data))
 }
 
 func (s *VpcService) SubnetAutoMatch(req *map[string]interface{}) {
@@ -563,7 +581,11 @@ func (s *VpcService) SubnetAutoMatch(req *map[string]interface{}) {
 }
 
 func (s *VpcService) CreateSubnetCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, false, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{false})
 	if err != nil {
 		return callback, err
 	}
@@ -607,11 +629,20 @@ func (s *VpcService) CreateSubnet(d *schema.ResourceData, r *schema.Resource) (e
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "subnet", false, true)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, tagCall}, d, s.client, true)
 }
 
 func (s *VpcService) ModifySubnetCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, true, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{false})
 	if err != nil {
 		return callback, err
 	}
@@ -640,7 +671,12 @@ func (s *VpcService) ModifySubnet(d *schema.ResourceData, r *schema.Resource) (e
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "subnet", true, false)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, tagCall}, d, s.client, true)
 }
 
 func (s *VpcService) RemoveSubnetCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -660,7 +696,7 @@ func (s *VpcService) RemoveSubnetCall(d *schema.ResourceData) (callback ApiCall,
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadSubnet(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading subnet when delete %q, %s", d.Id(), callErr))
@@ -735,7 +771,7 @@ func (s *VpcService) ReadRoute(d *schema.ResourceData, subnetId string) (data ma
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Route %s not exist ", subnetId)
+		return data, newNotFoundError(fmt.Sprintf("Route %s not exist ", subnetId))
 	}
 	return data, err
 }
@@ -867,7 +903,7 @@ func (s *VpcService) RemoveRouteCall(d *schema.ResourceData) (callback ApiCall,
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadRoute(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading route when delete %q, %s", d.Id(), callErr))
@@ -938,7 +974,7 @@ func (s *VpcService) ReadNat(d *schema.ResourceData, natId string) (data map[str
 	if err != nil {
 		return data, err
 	}
-	results, err = s.ReadNats(req)
+	results, err = s.client.batchDescribe("DescribeNats", "NatId", req, s.ReadNats)
 	if err != nil {
 		return data, err
 	}
@@ -946,7 +982,7 @@ func (s *VpcService) ReadNat(d *schema.ResourceData, natId string) (data map[str
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Nat %s not exist ", natId)
+		return data, newNotFoundError(fmt.Sprintf("Nat %s not exist ", natId))
 	}
 	return data, err
 }
@@ -986,7 +1022,7 @@ func (s *VpcService) ReadAndSetNats(d *schema.ResourceData, r *schema.Resource)
 				KeepAuto: false,
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "nat", "NatId", data))
 }
 
 func (s *VpcService) ReadAndSetNat(d *schema.ResourceData, r *schema.Resource) (err error) {
@@ -996,12 +1032,16 @@ func (s *VpcService) ReadAndSetNat(d *schema.ResourceData, r *schema.Resource) (
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading nat %q, %s", d.Id(), callErr))
 			}
 		} else {
+			err = mergeTagsData(d, &data, s.client, "nat")
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
 			SdkResponseAutoResourceData(d, r, data, chargeExtraForVpc(data))
 			return nil
 		}
@@ -1009,7 +1049,11 @@ func (s *VpcService) ReadAndSetNat(d *schema.ResourceData, r *schema.Resource) (
 }
 
 func (s *VpcService) CreateNatCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
-	req, err := SdkRequestAutoMapping(d, r, false, nil, nil)
+	transform := map[string]SdkReqTransform{
+		"tags":     {Ignore: true},
+		"tags_all": {Ignore: true},
+	}
+	req, err := SdkRequestAutoMapping(d, r, false, transform, nil, SdkReqParameter{false})
 	if err != nil {
 		return callback, err
 	}
@@ -1040,12 +1084,19 @@ func (s *VpcService) CreateNat(d *schema.ResourceData, r *schema.Resource) (err
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "nat", false, true)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{call, tagCall}, d, s.client, true)
 }
 
 func (s *VpcService) ModifyNatCall(d *schema.ResourceData, r *schema.Resource) (callback ApiCall, err error) {
 	transform := map[string]SdkReqTransform{
 		"project_id": {Ignore: true},
+		"tags":       {Ignore: true},
+		"tags_all":   {Ignore: true},
 	}
 	req, err := SdkRequestAutoMapping(d, r, true, transform, nil, SdkReqParameter{false})
 	if err != nil {
@@ -1102,7 +1153,12 @@ func (s *VpcService) ModifyNat(d *schema.ResourceData, r *schema.Resource) (err
 	if err != nil {
 		return err
 	}
-	return ksyunApiCallNew([]ApiCall{projectCall, call}, d, s.client, true)
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "nat", true, false)
+	if err != nil {
+		return err
+	}
+	return ksyunApiCallNew([]ApiCall{projectCall, call, tagCall}, d, s.client, true)
 }
 
 func (s *VpcService) RemoveNatCall(d *schema.ResourceData) (callback ApiCall, err error) {
@@ -1122,7 +1178,7 @@ func (s *VpcService) RemoveNatCall(d *schema.ResourceData) (callback ApiCall, er
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadNat(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading nat when delete %q, %s", d.Id(), callErr))
@@ -1155,7 +1211,7 @@ func (s *VpcService) ReadNatAssociate(d *schema.ResourceData, natId string, subn
 	data, err = s.ReadNat(d, natId)
 	if items, ok := data["AssociateNatSet"]; ok {
 		if len(items.([]interface{})) == 0 {
-			return data, fmt.Errorf("Subnet %s not exist in Nat %s ", subnetId, natId)
+			return data, newNotFoundError(fmt.Sprintf("Subnet %s not exist in Nat %s ", subnetId, natId))
 		}
 		found := false
 		for _, item := range items.([]interface{}) {
@@ -1165,10 +1221,10 @@ func (s *VpcService) ReadNatAssociate(d *schema.ResourceData, natId string, subn
 			}
 		}
 		if !found {
-			return data, fmt.Errorf("Subnet %s not exist in Nat %s ", subnetId, natId)
+			return data, newNotFoundError(fmt.Sprintf("Subnet %s not exist in Nat %s ", subnetId, natId))
 		}
 	} else {
-		return data, fmt.Errorf("Subnet %s not exist in Nat %s ", subnetId, natId)
+		return data, newNotFoundError(fmt.Sprintf("Subnet %s not exist in Nat %s ", subnetId, natId))
 	}
 	return data, err
 }
@@ -1232,7 +1288,7 @@ func (s *VpcService) RemoveNatAssociateCall(d *schema.ResourceData, natId string
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadNatAssociate(d, natId, subnetId)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading nat associate when delete %q, %s", d.Id(), callErr))
@@ -1307,7 +1363,7 @@ func (s *VpcService) ReadNetworkAcl(d *schema.ResourceData, networkAclId string)
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Acl %s not exist ", networkAclId)
+		return data, newNotFoundError(fmt.Sprintf("Acl %s not exist ", networkAclId))
 	}
 	return data, err
 }
@@ -1329,7 +1385,7 @@ func (s *VpcService) ReadNetworkAclEntry(d *schema.ResourceData, networkAclId st
 		}
 	}
 	if !found {
-		return data, fmt.Errorf("network acl not exist")
+		return data, newNotFoundError("network acl not exist")
 	}
 	return data, err
 }
@@ -1413,7 +1469,7 @@ func (s *VpcService) ReadAndSetNetworkAclAssociate(d *schema.ResourceData, r *sc
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(fmt.Errorf("error on  reading network acl associate %q, %s", d.Id(), callErr))
@@ -1808,7 +1864,7 @@ func (s *VpcService) RemoveNetworkAclCall(d *schema.ResourceData) (callback ApiC
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadNetworkAcl(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading network acl when delete %q, %s", d.Id(), callErr))
@@ -1847,7 +1903,7 @@ func (s *VpcService) RemoveNetworkAclEntryCommonCall(aclId string, entryId strin
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				data, callErr := s.ReadNetworkAcl(d, aclId)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading nat when delete %q, %s", d.Id(), callErr))
@@ -1903,7 +1959,7 @@ func (s *VpcService) RemoveNetworkAclAssociateCall(d *schema.ResourceData) (call
 				subnetId := (*(call.param))["SubnetId"].(string)
 				_, callErr := s.ReadNetworkAclAssociate(d, aclId, subnetId)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading network acl associate when delete %q, %s", d.Id(), callErr))
@@ -1949,31 +2005,27 @@ func (s *VpcService) RemoveNetworkAclAssociate(d *schema.ResourceData) (err erro
 }
 
 func (s *VpcService) ReadSecurityGroups(condition map[string]interface{}) (data []interface{}, err error) {
-	var (
-		resp    *map[string]interface{}
-		results interface{}
-	)
-	conn := s.client.vpcconn
-	action := "DescribeSecurityGroups"
-	logger.Debug(logger.ReqFormat, action, condition)
-	if condition == nil {
-		resp, err = conn.DescribeSecurityGroups(nil)
-		if err != nil {
-			return data, err
-		}
-	} else {
-		resp, err = conn.DescribeSecurityGroups(&condition)
-		if err != nil {
-			return data, err
-		}
-	}
-
-	results, err = getSdkValue("SecurityGroupSet", *resp)
-	if err != nil {
-		return data, err
-	}
-	data = results.([]interface{})
-	return data, err
+	return s.ReadSecurityGroupsPage(condition, 0)
+}
+
+// ReadSecurityGroupsPage lists at most maxResults security groups, 0 means all the security groups
+func (s *VpcService) ReadSecurityGroupsPage(condition map[string]interface{}, maxResults int) (data []interface{}, err error) {
+	return pageQueryResponse(condition, pageResponseOptions{
+		pageOptions: pageOptions{
+			limitParam: "MaxResults",
+			pageParam:  "NextToken",
+			limit:      1000,
+			byToken:    true,
+			maxResults: maxResults,
+		},
+		itemsField: "SecurityGroupSet",
+		tokenField: "NextToken",
+	}, func(condition map[string]interface{}) (*map[string]interface{}, error) {
+		conn := s.client.vpcconn
+		action := "DescribeSecurityGroups"
+		logger.Debug(logger.ReqFormat, action, condition)
+		return conn.DescribeSecurityGroups(&condition)
+	})
 }
 
 func (s *VpcService) ReadSecurityGroup(d *schema.ResourceData, securityGroupId string) (data map[string]interface{}, err error) {
@@ -1986,7 +2038,7 @@ func (s *VpcService) ReadSecurityGroup(d *schema.ResourceData, securityGroupId s
 	req := map[string]interface{}{
 		"SecurityGroupId.1": securityGroupId,
 	}
-	results, err = s.ReadSecurityGroups(req)
+	results, err = s.client.batchDescribe("DescribeSecurityGroups", "SecurityGroupId", req, s.ReadSecurityGroups)
 	if err != nil {
 		return data, err
 	}
@@ -1994,7 +2046,7 @@ func (s *VpcService) ReadSecurityGroup(d *schema.ResourceData, securityGroupId s
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("security group %s not exist ", securityGroupId)
+		return data, newNotFoundError(fmt.Sprintf("security group %s not exist ", securityGroupId))
 	}
 	return data, err
 }
@@ -2015,7 +2067,7 @@ func (s *VpcService) ReadSecurityGroupEntry(d *schema.ResourceData, securityGrou
 		}
 	}
 	if !found {
-		return data, fmt.Errorf("security group entry not exist")
+		return data, newNotFoundError("security group entry not exist")
 	}
 	return data, err
 }
@@ -2035,7 +2087,7 @@ func (s *VpcService) ReadAndSetSecurityGroups(d *schema.ResourceData, r *schema.
 	if err != nil {
 		return err
 	}
-	data, err := s.ReadSecurityGroups(req)
+	data, err := s.ReadSecurityGroupsPage(req, dataSourcePageLimit(d))
 	if err != nil {
 		return err
 	}
@@ -2055,7 +2107,7 @@ func (s *VpcService) ReadAndSetSecurityGroups(d *schema.ResourceData, r *schema.
 				KeepAuto: true,
 			},
 		},
-	})
+	}, tagsMatchPlugin(s.client, "security-group", "SecurityGroupId", data))
 }
 
 func (s *VpcService) ReadAndSetSecurityGroup(d *schema.ResourceData, r *schema.Resource) (err error) {
@@ -2063,6 +2115,10 @@ func (s *VpcService) ReadAndSetSecurityGroup(d *schema.ResourceData, r *schema.R
 	if err != nil {
 		return err
 	}
+	err = mergeTagsData(d, &data, s.client, "security-group")
+	if err != nil {
+		return err
+	}
 	extra := map[string]SdkResponseMapping{
 		"SecurityGroupEntrySet": {
 			Field: "security_group_entries",
@@ -2234,6 +2290,12 @@ func (s *VpcService) CreateSecurityGroup(d *schema.ResourceData, r *schema.Resou
 	for _, entryCall := range entries {
 		callbacks = append(callbacks, entryCall)
 	}
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "security-group", false, true)
+	if err != nil {
+		return err
+	}
+	callbacks = append(callbacks, tagCall)
 	return ksyunApiCallNew(callbacks, d, s.client, false)
 }
 
@@ -2404,6 +2466,12 @@ func (s *VpcService) ModifySecurityGroup(d *schema.ResourceData, r *schema.Resou
 	for _, entryCall := range entries {
 		callbacks = append(callbacks, entryCall)
 	}
+	tagService := TagService{s.client}
+	tagCall, err := tagService.ReplaceResourcesTagsWithResourceCall(d, r, "security-group", true, false)
+	if err != nil {
+		return err
+	}
+	callbacks = append(callbacks, tagCall)
 	return ksyunApiCallNew(callbacks, d, s.client, true)
 }
 
@@ -2434,7 +2502,7 @@ func (s *VpcService) RemoveSecurityGroupCall(d *schema.ResourceData) (callback A
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadSecurityGroup(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading security group when delete %q, %s", d.Id(), callErr))
@@ -2473,7 +2541,7 @@ func (s *VpcService) RemoveSecurityGroupEntryCommonCall(sgId string, entryId str
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				data, callErr := s.ReadSecurityGroup(d, sgId)
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading security group entry when delete %q, %s", d.Id(), callErr))
@@ -2575,7 +2643,7 @@ func (s *VpcService) ReadVpnGateway(d *schema.ResourceData, vpnGatewayId string)
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Vpn Gateway  %s not exist ", vpnGatewayId)
+		return data, newNotFoundError(fmt.Sprintf("Vpn Gateway  %s not exist ", vpnGatewayId))
 	}
 	return data, err
 }
@@ -2587,7 +2655,7 @@ func (s *VpcService) ReadAndSetVpnGateway(d *schema.ResourceData, r *schema.Reso
 			if !d.IsNewResource() {
 				return resource.NonRetryableError(callErr)
 			}
-			if notFoundError(callErr) {
+			if isNotFoundError(callErr) {
 				return resource.RetryableError(callErr)
 			} else {
 				return resource.NonRetryableError(callErr)
@@ -2729,7 +2797,7 @@ func (s *VpcService) RemoveVpnGatewayCall(d *schema.ResourceData) (callback ApiC
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadVpnGateway(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading vpn gateway when delete %q, %s", d.Id(), callErr))
@@ -2804,7 +2872,7 @@ func (s *VpcService) ReadVpnCustomerGateway(d *schema.ResourceData, vpnCustomerG
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Customer gateway %s not exist ", vpnCustomerGatewayId)
+		return data, newNotFoundError(fmt.Sprintf("Customer gateway %s not exist ", vpnCustomerGatewayId))
 	}
 	return data, err
 }
@@ -2937,7 +3005,7 @@ func (s *VpcService) RemoveVpnCustomerGatewayCall(d *schema.ResourceData) (callb
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadVpnCustomerGateway(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading customer gateway when delete %q, %s", d.Id(), callErr))
@@ -3012,7 +3080,7 @@ func (s *VpcService) ReadVpnTunnel(d *schema.ResourceData, vpnTunnelId string) (
 		data = v.(map[string]interface{})
 	}
 	if len(data) == 0 {
-		return data, fmt.Errorf("Vpn tunnel %s not exist ", vpnTunnelId)
+		return data, newNotFoundError(fmt.Sprintf("Vpn tunnel %s not exist ", vpnTunnelId))
 	}
 	return data, err
 }
@@ -3191,7 +3259,7 @@ func (s *VpcService) RemoveVpnTunnelCall(d *schema.ResourceData) (callback ApiCa
 			return resource.Retry(15*time.Minute, func() *resource.RetryError {
 				_, callErr := s.ReadVpnTunnel(d, "")
 				if callErr != nil {
-					if notFoundError(callErr) {
+					if isNotFoundError(callErr) {
 						return nil
 					} else {
 						return resource.NonRetryableError(fmt.Errorf("error on  reading vpn tunnel when delete %q, %s", d.Id(), callErr))
@@ -81,7 +81,8 @@ func writeToFile(filePath string, data interface{}) error {
 		}
 	}
 
-	return ioutil.WriteFile(absPath, bs, 0422)
+	// the output may contain sensitive data, so only the owner can read it
+	return ioutil.WriteFile(absPath, bs, 0600)
 }
 
 func getAbsPath(filePath string) (string, error) {
@@ -148,6 +149,8 @@ type SdkSliceData struct {
 	IdMappingFunc    IdMappingFunc
 	SliceMappingFunc SliceMappingFunc
 	TargetName       string
+	// filtered is set when the filter blocks of the data source have been applied before mapping
+	filtered bool
 }
 
 func sliceMapping(ids []string, data []map[string]interface{}, sdkSliceData SdkSliceData, item interface{}) ([]string, []map[string]interface{}) {
@@ -776,6 +779,10 @@ func SdkSliceMapping(d *schema.ResourceData, result interface{}, sdkSliceData Sd
 		}
 
 		if d != nil && sdkSliceData.TargetName != "" {
+			if !sdkSliceData.filtered {
+				ids, data = filterDataSourceResults(d, ids, data)
+			}
+			length = len(data)
 			d.SetId(hashStringArray(ids))
 			_ = d.Set("total_count", length)
 			err = d.Set(sdkSliceData.TargetName, data)
@@ -783,7 +790,7 @@ func SdkSliceMapping(d *schema.ResourceData, result interface{}, sdkSliceData Sd
 				return nil, nil, err
 			}
 			if outputFile, ok := d.GetOk("output_file"); ok && outputFile.(string) != "" {
-				err = writeToFile(outputFile.(string), data)
+				err = writeDataSourceOutput(d, sdkSliceData.TargetName, outputFile.(string), data)
 				if err != nil {
 					return nil, nil, err
 				}
@@ -1,7 +1,6 @@
 package ksyun
 
 import (
-	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -52,11 +51,14 @@ func (conf *IntegrationRedisAzConf) integrationRedisAz() (*map[string]interface{
 	if v, ok := conf.resourceData.GetOk(conf.field); ok {
 		(*conf.req)[Downline2Hump(conf.field)] = v
 	}
-	resp, _ = conf.requestFunc()
-	if err == nil && (conf.existFn == nil || (conf.existFn != nil && conf.existFn(resp))) {
-		_ = conf.resourceData.Set(conf.field, (*conf.req)["AvailableZone"])
+	resp, err = conf.requestFunc()
+	if err != nil {
 		return resp, err
 	}
-	return resp, fmt.Errorf(" not exist ")
+	if conf.existFn != nil && !conf.existFn(resp) {
+		return resp, newNotFoundError("the resource does not exist in the response")
+	}
+	_ = conf.resourceData.Set(conf.field, (*conf.req)["AvailableZone"])
+	return resp, err
 
 }
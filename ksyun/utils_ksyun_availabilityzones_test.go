@@ -0,0 +1,42 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrationRedisAz(t *testing.T) {
+	a := assert.New(t)
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"available_zone": {Type: schema.TypeString, Optional: true, Computed: true},
+	}, map[string]interface{}{"available_zone": "cn-beijing-6b"})
+	req := map[string]interface{}{"CacheId": "cache-1"}
+	conf := &IntegrationRedisAzConf{
+		resourceData: d,
+		req:          &req,
+		field:        "available_zone",
+		requestFunc: func() (*map[string]interface{}, error) {
+			return nil, newServiceRequestFailure("kcs", awserr.NewRequestFailure(awserr.New("InvalidAction", "", nil), 400, "test"))
+		},
+	}
+	_, err := conf.integrationRedisAz()
+	a.True(isNotFoundError(err))
+	a.Equal("cn-beijing-6b", req["AvailableZone"])
+
+	conf.requestFunc = func() (*map[string]interface{}, error) {
+		return &map[string]interface{}{"Data": map[string]interface{}{}}, nil
+	}
+	conf.existFn = func(resp *map[string]interface{}) bool {
+		return len((*resp)["Data"].(map[string]interface{})) > 0
+	}
+	_, err = conf.integrationRedisAz()
+	a.True(isNotFoundError(err))
+
+	conf.existFn = nil
+	resp, err := conf.integrationRedisAz()
+	a.Nil(err)
+	a.NotNil(resp)
+}
@@ -0,0 +1,161 @@
+package ksyun
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultDescribeBatchWindow is how long a describe call of one resource waits for the concurrent ones to merge with
+	defaultDescribeBatchWindow = 20 * time.Millisecond
+	// maxDescribeBatchSize is the most ids in one describe call, the batch is sent at once when it is full
+	maxDescribeBatchSize = 100
+)
+
+// describeBatcher merges the concurrent describe calls of single resources with the same action and params
+// into one describe call with the ids as IdParam.N, then fans the items out to each caller
+type describeBatcher struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[string]*describeBatch
+}
+
+type describeBatch struct {
+	ids     []string
+	started bool
+	done    chan struct{}
+	items   map[string]interface{}
+	err     error
+}
+
+func newDescribeBatcher(window time.Duration) *describeBatcher {
+	return &describeBatcher{
+		window:  window,
+		pending: make(map[string]*describeBatch),
+	}
+}
+
+// batchDescribe reads the resource whose id is condition[idParam.1] by read, and returns the same items as read(condition),
+// read is called once for the concurrent calls of action whose other params are equal
+func (client *KsyunClient) batchDescribe(action, idParam string, condition map[string]interface{},
+	read func(condition map[string]interface{}) ([]interface{}, error)) ([]interface{}, error) {
+	id, ok := condition[idParam+".1"].(string)
+	if !ok || client.describeBatcher == nil || client.describeBatcher.window <= 0 {
+		return read(condition)
+	}
+	for k := range condition {
+		if k != idParam+".1" && strings.HasPrefix(k, idParam+".") {
+			return read(condition)
+		}
+	}
+	return client.describeBatcher.read(action, idParam, id, condition, read)
+}
+
+func (b *describeBatcher) read(action, idParam, id string, condition map[string]interface{},
+	read func(condition map[string]interface{}) ([]interface{}, error)) ([]interface{}, error) {
+	params := make(map[string]interface{}, len(condition))
+	for k, v := range condition {
+		if k != idParam+".1" {
+			params[k] = v
+		}
+	}
+	key := describeBatchKey(action, params)
+
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &describeBatch{done: make(chan struct{})}
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() {
+			b.flush(key, batch, idParam, params, read)
+		})
+	}
+	if !batch.has(id) {
+		batch.ids = append(batch.ids, id)
+	}
+	full := len(batch.ids) >= maxDescribeBatchSize
+	b.mu.Unlock()
+	if full {
+		b.flush(key, batch, idParam, params, read)
+	}
+
+	<-batch.done
+	if batch.err != nil {
+		if len(batch.ids) > 1 {
+			// one bad id fails the whole call, so every resource of the failed batch is read alone
+			return read(condition)
+		}
+		return nil, batch.err
+	}
+	item, ok := batch.items[id].(map[string]interface{})
+	if !ok {
+		return []interface{}{}, nil
+	}
+	copied := make(map[string]interface{}, len(item))
+	for k, v := range item {
+		copied[k] = v
+	}
+	return []interface{}{copied}, nil
+}
+
+func (batch *describeBatch) has(id string) bool {
+	for _, v := range batch.ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// flush sends the batch once, either when the window ends or when the batch is full
+func (b *describeBatcher) flush(key string, batch *describeBatch, idParam string, params map[string]interface{},
+	read func(condition map[string]interface{}) ([]interface{}, error)) {
+	b.mu.Lock()
+	if batch.started {
+		b.mu.Unlock()
+		return
+	}
+	batch.started = true
+	if b.pending[key] == batch {
+		delete(b.pending, key)
+	}
+	b.mu.Unlock()
+
+	defer close(batch.done)
+	condition := make(map[string]interface{}, len(params)+len(batch.ids))
+	for k, v := range params {
+		condition[k] = v
+	}
+	for i, id := range batch.ids {
+		condition[fmt.Sprintf("%s.%d", idParam, i+1)] = id
+	}
+	results, err := read(condition)
+	if err != nil {
+		batch.err = err
+		return
+	}
+	batch.items = make(map[string]interface{}, len(results))
+	for _, result := range results {
+		if item, ok := result.(map[string]interface{}); ok {
+			if id, ok := item[idParam].(string); ok {
+				batch.items[id] = item
+			}
+		}
+	}
+}
+
+func describeBatchKey(action string, params map[string]interface{}) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	key := action
+	for _, k := range keys {
+		key += fmt.Sprintf("&%s=%v", k, params[k])
+	}
+	return key
+}
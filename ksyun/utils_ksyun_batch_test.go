@@ -0,0 +1,149 @@
+package ksyun
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchDescribe(t *testing.T) {
+	a := assert.New(t)
+	client := &KsyunClient{describeBatcher: newDescribeBatcher(50 * time.Millisecond)}
+	var calls int32
+	read := func(condition map[string]interface{}) ([]interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		var items []interface{}
+		for i := 1; ; i++ {
+			id, ok := condition[fmt.Sprintf("InstanceId.%d", i)]
+			if !ok {
+				break
+			}
+			if id == "i-bad" {
+				return nil, fmt.Errorf("invalid instance id %s", id)
+			}
+			if id != "i-missing" {
+				items = append(items, map[string]interface{}{"InstanceId": id, "ProjectId": condition["ProjectId.1"]})
+			}
+		}
+		return items, nil
+	}
+	readAll := func(ids []string, project string) [][]interface{} {
+		results := make([][]interface{}, len(ids))
+		var wg sync.WaitGroup
+		for i, id := range ids {
+			wg.Add(1)
+			go func(i int, id string) {
+				defer wg.Done()
+				var err error
+				results[i], err = client.batchDescribe("DescribeInstances", "InstanceId", map[string]interface{}{
+					"InstanceId.1": id,
+					"ProjectId.1":  project,
+				}, read)
+				if err != nil {
+					results[i] = []interface{}{err}
+				}
+			}(i, id)
+		}
+		wg.Wait()
+		return results
+	}
+
+	var ids []string
+	for i := 0; i < 20; i++ {
+		ids = append(ids, fmt.Sprintf("i-%d", i))
+	}
+	ids = append(ids, "i-missing", "i-0")
+	results := readAll(ids, "0")
+	a.Equal(int32(1), atomic.LoadInt32(&calls))
+	for i, id := range ids {
+		if id == "i-missing" {
+			a.Empty(results[i])
+			continue
+		}
+		a.Len(results[i], 1)
+		a.Equal(id, results[i][0].(map[string]interface{})["InstanceId"])
+	}
+
+	// the calls with different params are not merged
+	atomic.StoreInt32(&calls, 0)
+	var wg sync.WaitGroup
+	for _, project := range []string{"1", "2"} {
+		wg.Add(1)
+		go func(project string) {
+			defer wg.Done()
+			results := readAll([]string{"i-1", "i-2"}, project)
+			a.Equal(project, results[0][0].(map[string]interface{})["ProjectId"])
+		}(project)
+	}
+	wg.Wait()
+	a.Equal(int32(2), atomic.LoadInt32(&calls))
+
+	// the resources of a failed batch are read alone
+	atomic.StoreInt32(&calls, 0)
+	results = readAll([]string{"i-1", "i-bad", "i-2"}, "0")
+	a.Equal(int32(4), atomic.LoadInt32(&calls))
+	a.Equal("i-1", results[0][0].(map[string]interface{})["InstanceId"])
+	a.Error(results[1][0].(error))
+	a.Equal("i-2", results[2][0].(map[string]interface{})["InstanceId"])
+
+	// the full batch is sent without waiting for the window
+	client.describeBatcher.window = time.Minute
+	atomic.StoreInt32(&calls, 0)
+	ids = nil
+	for i := 0; i < maxDescribeBatchSize; i++ {
+		ids = append(ids, fmt.Sprintf("i-%d", i))
+	}
+	start := time.Now()
+	readAll(ids, "0")
+	a.True(time.Since(start) < time.Minute)
+	a.Equal(int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestBatchDescribeFakeApi(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	c := Config{
+		AccessKey: fakeApiAccessKey,
+		SecretKey: fakeApiSecretKey,
+		Region:    fakeApiRegion,
+		Endpoints: map[string]string{"vpc": api.server.URL},
+	}
+	client, err := c.Client()
+	a.Nil(err)
+
+	var vpcIds []string
+	for i := 0; i < 10; i++ {
+		resp, err := client.vpcconn.CreateVpc(&map[string]interface{}{
+			"VpcName":   fmt.Sprintf("tf-vpc-%d", i),
+			"CidrBlock": "10.0.0.0/16",
+		})
+		a.Nil(err)
+		vpcIds = append(vpcIds, (*resp)["Vpc"].(map[string]interface{})["VpcId"].(string))
+	}
+	vpcIds = append(vpcIds, "vpc-not-exist")
+
+	vpcService := VpcService{client}
+	errs := make([]error, len(vpcIds))
+	names := make([]interface{}, len(vpcIds))
+	var wg sync.WaitGroup
+	for i, id := range vpcIds {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			data, err := vpcService.ReadVpc(nil, id)
+			errs[i] = err
+			names[i] = data["VpcName"]
+		}(i, id)
+	}
+	wg.Wait()
+	a.Equal(1, api.called("vpc", "DescribeVpcs"))
+	for i := 0; i < 10; i++ {
+		a.Nil(errs[i])
+		a.Equal(fmt.Sprintf("tf-vpc-%d", i), names[i])
+	}
+	a.True(isNotFoundError(errs[10]))
+}
@@ -2,7 +2,17 @@ package ksyun
 
 import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = time.Second
+	maxRetryBackoff     = 30 * time.Second
 )
 
 type ApiCall struct {
@@ -94,3 +104,48 @@ func ksyunApiCallProcess(api []ApiCall, d *schema.ResourceData, client *KsyunCli
 	}
 	return err
 }
+
+// apiRetryer is the retryer of the ksc sdk session, it is the only layer retrying the api calls.
+// the actions which only read are retried on throttling, server side and transport errors, the other actions
+// are only retried on throttling and resources in use, which are rejected before executing, so a lost response
+// never creates a resource twice
+type apiRetryer struct {
+	maxRetries int
+	backoff    time.Duration
+}
+
+func (r apiRetryer) MaxRetries() int {
+	return r.maxRetries
+}
+
+func (r apiRetryer) RetryRules(req *request.Request) time.Duration {
+	return retryBackoff(r.backoff, req.RetryCount)
+}
+
+func (r apiRetryer) ShouldRetry(req *request.Request) bool {
+	action := ""
+	if req.Operation != nil {
+		action = req.Operation.Name
+	}
+	retry := retryableActionError(action, req.Error)
+	if retry {
+		logger.Debug(logger.ErrFormat, action, "retry", req.Error)
+	}
+	return retry
+}
+
+// retryBackoff returns a random duration in [backoff/2, backoff), backoff is base*2^attempt and limited by maxRetryBackoff
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		base = defaultRetryBackoff
+	}
+	backoff := base
+	for i := 0; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
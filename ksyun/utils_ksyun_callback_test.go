@@ -0,0 +1,110 @@
+package ksyun
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestApiRetryer(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	failures := map[string]error{
+		"DescribeVpcs": newFakeApiError(500, "InternalError", "internal error"),
+		"CreateVpc":    newFakeApiError(500, "InternalError", "internal error"),
+		"DeleteVpc":    newFakeApiError(429, "Throttling", "request limit exceeded"),
+	}
+	for action, failure := range failures {
+		failure := failure
+		api.handle("vpc", action, func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+			return nil, failure
+		})
+	}
+
+	c := Config{
+		AccessKey:    fakeApiAccessKey,
+		SecretKey:    fakeApiSecretKey,
+		Region:       fakeApiRegion,
+		Endpoints:    map[string]string{"vpc": api.server.URL},
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+	conflicts := 1
+	api.handle("vpc", "DeleteSubnet", func(api *fakeKsyunApi, req *fakeApiRequest) (map[string]interface{}, error) {
+		if conflicts > 0 {
+			conflicts--
+			return nil, newFakeApiError(400, "DependencyViolation", "the subnet is in use")
+		}
+		return map[string]interface{}{"Return": true}, nil
+	})
+
+	client, err := c.Client()
+	a.Nil(err)
+	_, err = client.vpcconn.DescribeVpcs(&map[string]interface{}{})
+	a.NotNil(err)
+	a.Equal(3, api.called("vpc", "DescribeVpcs"))
+	// a resource in use rejects the change before executing it, so the change is sent again
+	_, err = client.vpcconn.DeleteSubnet(&map[string]interface{}{"SubnetId": "subnet"})
+	a.Nil(err)
+	a.Equal(2, api.called("vpc", "DeleteSubnet"))
+	// a lost response of the action creating resource must not create it twice
+	_, err = client.vpcconn.CreateVpc(&map[string]interface{}{"CidrBlock": "10.0.0.0/16"})
+	a.NotNil(err)
+	a.Equal(1, api.called("vpc", "CreateVpc"))
+	_, err = client.vpcconn.DeleteVpc(&map[string]interface{}{"VpcId": "vpc"})
+	a.NotNil(err)
+	a.Equal(3, api.called("vpc", "DeleteVpc"))
+
+	c.MaxRetries = 0
+	client, err = c.Client()
+	a.Nil(err)
+	_, err = client.vpcconn.DescribeVpcs(&map[string]interface{}{})
+	a.NotNil(err)
+	a.Equal(4, api.called("vpc", "DescribeVpcs"))
+}
+
+func TestRetryableActionError(t *testing.T) {
+	a := assert.New(t)
+	throttled := awserr.NewRequestFailure(awserr.New("Throttling", "request limit exceeded", nil), 429, "test")
+	internal := awserr.NewRequestFailure(awserr.New("InternalError", "internal error", nil), 500, "test")
+	lost := awserr.New("RequestError", "send request failed", nil)
+	a.True(retryableActionError("DescribeInstances", internal))
+	a.True(retryableActionError("DescribeInstances", lost))
+	a.True(retryableActionError("RunInstances", throttled))
+	a.False(retryableActionError("RunInstances", internal))
+	a.False(retryableActionError("AllocateAddress", lost))
+	a.False(retryableActionError("", internal))
+
+	inUse := func(service, code string) error {
+		return newServiceRequestFailure(service, awserr.NewRequestFailure(awserr.New(code, "", nil), 400, "test"))
+	}
+	a.True(retryableActionError("DeleteSubnet", inUse("vpc", "DependencyViolation")))
+	a.True(retryableActionError("TerminateInstances", inUse("kec", "IncorrectInstanceStatus")))
+	a.True(retryableActionError("DetachVolume", inUse("ebs", "InvalidVolumeStatus")))
+	a.True(retryableActionError("DeleteSecurityGroup", awserr.NewRequestFailure(awserr.New("Conflict", "the security group is in use", nil), 409, "test")))
+	a.False(retryableActionError("CreateUser", inUse("iam", "EntityAlreadyExists")))
+}
+
+func TestRetryableError(t *testing.T) {
+	a := assert.New(t)
+	a.True(retryableError(awserr.NewRequestFailure(awserr.New("ServiceUnavailable", "", nil), 503, "test")))
+	a.True(retryableError(awserr.NewRequestFailure(awserr.New("RequestLimitExceeded", "", nil), 400, "test")))
+	a.True(retryableError(awserr.NewRequestFailure(awserr.New("Conflict", "the security group is in use", nil), 409, "test")))
+	a.True(retryableError(awserr.New("RequestError", "send request failed", nil)))
+	a.False(retryableError(awserr.NewRequestFailure(awserr.New("InvalidParameter", "", nil), 400, "test")))
+	a.False(retryableError(fmt.Errorf("not a api error")))
+	a.False(retryableError(nil))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	a := assert.New(t)
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := retryBackoff(time.Second, attempt)
+		a.True(wait >= 500*time.Millisecond)
+		a.True(wait <= maxRetryBackoff)
+	}
+	a.True(retryBackoff(time.Second, 3) >= 4*time.Second)
+}
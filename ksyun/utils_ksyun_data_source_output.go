@@ -0,0 +1,206 @@
+package ksyun
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/terraform-providers/terraform-provider-ksyun/logger"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	outputFormatJson = "json"
+	outputFormatYaml = "yaml"
+	outputFormatCsv  = "csv"
+)
+
+// dataSourceOutputFormatSchema is the format of the output_file of data sources
+func dataSourceOutputFormatSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      outputFormatJson,
+		ValidateFunc: validation.StringInSlice([]string{outputFormatJson, outputFormatYaml, outputFormatCsv}, false),
+	}
+}
+
+// writeDataSourceOutput writes the results of a data source to filePath in the output_format of the data source,
+// the sensitive attributes of the results in dataKey are removed before writing
+func writeDataSourceOutput(d *schema.ResourceData, dataKey string, filePath string, data interface{}) error {
+	format := outputFormatJson
+	if v, ok := d.GetOk("output_format"); ok {
+		format = v.(string)
+	}
+	sensitive, _ := dataSourceSensitiveAttributes()[dataKey].(sensitiveAttributes)
+	output, err := formatDataSourceOutput(format, data, sensitive)
+	if err != nil {
+		return err
+	}
+	return writeToFile(filePath, output)
+}
+
+func formatDataSourceOutput(format string, data interface{}, sensitive sensitiveAttributes) (string, error) {
+	value, err := scrubDataSourceOutput(data, sensitive)
+	if err != nil {
+		return "", err
+	}
+	var bs []byte
+	switch format {
+	case outputFormatYaml:
+		bs, err = yaml.Marshal(value)
+	case outputFormatCsv:
+		bs, err = dataSourceOutputCsv(value)
+	default:
+		bs, err = json.MarshalIndent(value, "", "\t")
+	}
+	if err != nil {
+		return "", fmt.Errorf("error on formatting the output in %s, %s", format, err)
+	}
+	return string(bs), nil
+}
+
+// sensitiveAttributes is the tree of the sensitive attributes in a schema, the value of a sensitive attribute is true
+// and the value of a block with sensitive nested attributes is the sensitiveAttributes of the block
+type sensitiveAttributes map[string]interface{}
+
+var (
+	dataSourceSensitiveOnce sync.Once
+	dataSourceSensitive     sensitiveAttributes
+)
+
+// dataSourceSensitiveAttributes returns the attributes marked Sensitive in the schemas of all the data sources,
+// the data sources sharing a result field such as instances are merged so an attribute sensitive in any of them is removed
+func dataSourceSensitiveAttributes() sensitiveAttributes {
+	dataSourceSensitiveOnce.Do(func() {
+		dataSourceSensitive = make(sensitiveAttributes)
+		for _, r := range Provider().(*schema.Provider).DataSourcesMap {
+			dataSourceSensitive.merge(r.Schema)
+		}
+	})
+	return dataSourceSensitive
+}
+
+func (attributes sensitiveAttributes) merge(schemas map[string]*schema.Schema) {
+	for k, v := range schemas {
+		if v.Sensitive {
+			attributes[k] = true
+			continue
+		}
+		elem, ok := v.Elem.(*schema.Resource)
+		if !ok || attributes[k] == true {
+			continue
+		}
+		nested, _ := attributes[k].(sensitiveAttributes)
+		if nested == nil {
+			nested = make(sensitiveAttributes)
+		}
+		nested.merge(elem.Schema)
+		if len(nested) > 0 {
+			attributes[k] = nested
+		}
+	}
+}
+
+// scrubDataSourceOutput converts data to plain json values without the sensitive attributes,
+// the attributes not marked Sensitive are still removed when their names match the redact keys of logs
+// ignoring the underscores, such as instance_password and private_key
+func scrubDataSourceOutput(data interface{}, sensitive sensitiveAttributes) (interface{}, error) {
+	bs, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error on marshaling the output %#v, %s", data, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+	decoder.UseNumber()
+	var value interface{}
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return scrubOutputValue(value, sensitive), nil
+}
+
+func scrubOutputValue(v interface{}, sensitive sensitiveAttributes) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			if sensitive[k] == true || logger.IsRedactKey(strings.Replace(k, "_", "", -1)) {
+				delete(value, k)
+			} else {
+				nested, _ := sensitive[k].(sensitiveAttributes)
+				value[k] = scrubOutputValue(item, nested)
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = scrubOutputValue(item, sensitive)
+		}
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	}
+	return v
+}
+
+// dataSourceOutputCsv writes one row of each item, the columns are the sorted fields of all the items,
+// the nested values are written in json and the items which are not objects are written in the value column
+func dataSourceOutputCsv(value interface{}) ([]byte, error) {
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	var rows []map[string]interface{}
+	columns := make(map[string]bool)
+	for _, item := range items {
+		row, ok := item.(map[string]interface{})
+		if !ok {
+			row = map[string]interface{}{"value": item}
+		}
+		for k := range row {
+			columns[k] = true
+		}
+		rows = append(rows, row)
+	}
+	var header []string
+	for k := range columns {
+		header = append(header, k)
+	}
+	sort.Strings(header)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		record := make([]string, len(header))
+		for i, k := range header {
+			switch v := row[k].(type) {
+			case nil:
+			case string:
+				record[i] = v
+			case map[string]interface{}, []interface{}:
+				bs, err := json.Marshal(v)
+				if err != nil {
+					return nil, err
+				}
+				record[i] = string(bs)
+			default:
+				record[i] = fmt.Sprintf("%v", v)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,151 @@
+package ksyun
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// singularDataSource describes a data source looking up exactly one item by the list data source of the same resource
+type singularDataSource struct {
+	// list is the list data source, its arguments are the arguments of the singular data source
+	list func() *schema.Resource
+	// targetField is the field of the items in the list data source, their attributes are the attributes of the singular data source
+	targetField string
+	// idField is the attribute of item used as the id of the singular data source
+	idField string
+	// name is the resource name in the errors
+	name string
+	// mostRecentField enables most_recent, which picks the item with the greatest value of the field when more than one match
+	mostRecentField string
+}
+
+// singularDataSourceIgnoreFields is the fields of list data sources which make no sense for a single item
+var singularDataSourceIgnoreFields = []string{"output_file", "output_format", "total_count", "max_results"}
+
+// resource returns the singular data source, whose read fails unless exactly one item matches the arguments
+func (s singularDataSource) resource() *schema.Resource {
+	list := s.list()
+	attributes := list.Schema[s.targetField].Elem.(*schema.Resource).Schema
+	result := make(map[string]*schema.Schema)
+	for k, v := range list.Schema {
+		if k == s.targetField || isSingularDataSourceIgnoreField(k) {
+			continue
+		}
+		arg := *v
+		// the argument of the same primitive type as the attribute is also set by the attribute of the item
+		if attr, ok := attributes[k]; ok && attr.Type == arg.Type && isPrimitiveType(arg.Type) && arg.Default == nil {
+			arg.Computed = true
+		}
+		result[k] = &arg
+	}
+	for k, v := range attributes {
+		if _, ok := result[k]; !ok {
+			result[k] = computedSchema(v)
+		}
+	}
+	if s.mostRecentField != "" {
+		result["most_recent"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+	}
+	return &schema.Resource{
+		Read:   s.read,
+		Schema: result,
+	}
+}
+
+func (s singularDataSource) read(d *schema.ResourceData, meta interface{}) error {
+	list := s.list()
+	listData := list.Data(nil)
+	for k := range list.Schema {
+		if k == s.targetField || isSingularDataSourceIgnoreField(k) {
+			continue
+		}
+		if v, ok := d.GetOk(k); ok {
+			if err := listData.Set(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	if err := list.Read(listData, meta); err != nil {
+		return err
+	}
+
+	items, _ := listData.Get(s.targetField).([]interface{})
+	if len(items) > 1 && s.mostRecentField != "" && d.Get("most_recent").(bool) {
+		sort.SliceStable(items, func(i, j int) bool {
+			return fmt.Sprintf("%v", items[i].(map[string]interface{})[s.mostRecentField]) >
+				fmt.Sprintf("%v", items[j].(map[string]interface{})[s.mostRecentField])
+		})
+		items = items[:1]
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("no %s matched the query, change the arguments to match exactly one %s", s.name, s.name)
+	}
+	if len(items) > 1 {
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, fmt.Sprintf("%v", item.(map[string]interface{})[s.idField]))
+		}
+		message := fmt.Sprintf("%d %ss matched the query (%s), change the arguments to match exactly one %s",
+			len(items), s.name, strings.Join(ids, ", "), s.name)
+		if s.mostRecentField != "" {
+			message += " or set most_recent = true"
+		}
+		return errors.New(message)
+	}
+
+	item := items[0].(map[string]interface{})
+	attributes := list.Schema[s.targetField].Elem.(*schema.Resource).Schema
+	schemaMap := s.resource().Schema
+	for k := range attributes {
+		if v, ok := schemaMap[k]; !ok || !v.Computed {
+			continue
+		}
+		if err := d.Set(k, item[k]); err != nil {
+			return fmt.Errorf("error on setting %s of %s, %s", k, s.name, err)
+		}
+	}
+	d.SetId(fmt.Sprintf("%v", item[s.idField]))
+	return nil
+}
+
+// computedSchema copies the schema of a list data source attribute as a computed attribute
+func computedSchema(v *schema.Schema) *schema.Schema {
+	result := &schema.Schema{
+		Type:      v.Type,
+		Computed:  true,
+		Sensitive: v.Sensitive,
+		Set:       v.Set,
+	}
+	switch elem := v.Elem.(type) {
+	case *schema.Schema:
+		result.Elem = &schema.Schema{Type: elem.Type}
+	case *schema.Resource:
+		nested := make(map[string]*schema.Schema)
+		for k, s := range elem.Schema {
+			nested[k] = computedSchema(s)
+		}
+		result.Elem = &schema.Resource{Schema: nested}
+	}
+	return result
+}
+
+func isPrimitiveType(t schema.ValueType) bool {
+	return t == schema.TypeString || t == schema.TypeInt || t == schema.TypeBool || t == schema.TypeFloat
+}
+
+func isSingularDataSourceIgnoreField(k string) bool {
+	for _, f := range singularDataSourceIgnoreFields {
+		if k == f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package ksyun
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSingularDataSourceList(items []interface{}) func() *schema.Resource {
+	return func() *schema.Resource {
+		return &schema.Resource{
+			Read: func(d *schema.ResourceData, meta interface{}) error {
+				d.SetId("list")
+				return d.Set("images", items)
+			},
+			Schema: map[string]*schema.Schema{
+				"name_regex":  {Type: schema.TypeString, Optional: true},
+				"output_file": {Type: schema.TypeString, Optional: true},
+				"total_count": {Type: schema.TypeInt, Computed: true},
+				"images": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"image_id":      {Type: schema.TypeString, Computed: true},
+							"name":          {Type: schema.TypeString, Computed: true},
+							"creation_date": {Type: schema.TypeString, Computed: true},
+						},
+					},
+				},
+			},
+		}
+	}
+}
+
+func TestSingularDataSource(t *testing.T) {
+	a := assert.New(t)
+	items := []interface{}{
+		map[string]interface{}{"image_id": "img-1", "name": "centos", "creation_date": "2020-01-02T00:00:00Z"},
+		map[string]interface{}{"image_id": "img-2", "name": "centos", "creation_date": "2020-03-04T00:00:00Z"},
+	}
+	ds := singularDataSource{
+		list:            testSingularDataSourceList(items),
+		targetField:     "images",
+		idField:         "image_id",
+		name:            "image",
+		mostRecentField: "creation_date",
+	}
+	r := ds.resource()
+	a.Nil(r.InternalValidate(nil, false))
+	a.NotContains(r.Schema, "output_file")
+	a.NotContains(r.Schema, "total_count")
+	a.True(r.Schema["name"].Computed)
+
+	d := r.Data(nil)
+	err := r.Read(d, nil)
+	a.EqualError(err, "2 images matched the query (img-1, img-2), change the arguments to match exactly one image or set most_recent = true")
+
+	a.Nil(d.Set("most_recent", true))
+	a.Nil(r.Read(d, nil))
+	a.Equal("img-2", d.Id())
+	a.Equal("2020-03-04T00:00:00Z", d.Get("creation_date"))
+
+	ds.list = testSingularDataSourceList(nil)
+	a.EqualError(ds.resource().Read(ds.resource().Data(nil), nil), "no image matched the query, change the arguments to match exactly one image")
+}
@@ -1,8 +1,11 @@
 package ksyun
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 type ksyunDataSource struct {
@@ -30,6 +33,26 @@ func mergeDataSourcesReq(d *schema.ResourceData, r *schema.Resource, transform m
 			Ignore: true,
 		}
 	}
+	if _, ok := transform["output_format"]; !ok {
+		transform["output_format"] = SdkReqTransform{
+			Ignore: true,
+		}
+	}
+	if _, ok := transform["filter"]; !ok {
+		transform["filter"] = SdkReqTransform{
+			Ignore: true,
+		}
+	}
+	if _, ok := transform["max_results"]; !ok {
+		transform["max_results"] = SdkReqTransform{
+			Ignore: true,
+		}
+	}
+	if _, ok := transform["tags"]; !ok {
+		transform["tags"] = SdkReqTransform{
+			Ignore: true,
+		}
+	}
 	return SdkRequestAutoMapping(d, r, false, transform, nil,
 		SdkReqParameter{false})
 }
@@ -39,6 +62,10 @@ func mergeDataSourcesResp(d *schema.ResourceData, r *schema.Resource, dataSource
 		result []map[string]interface{}
 	)
 
+	filters := newDataSourceFilters(d)
+	if filters != nil {
+		plugIns = append(plugIns, filtersMatchPlugin(r, dataSource, filters))
+	}
 	if plugIns != nil && len(plugIns) > 0 {
 		for _, plugIn := range plugIns {
 			var filter []interface{}
@@ -80,6 +107,11 @@ func mergeDataSourcesResp(d *schema.ResourceData, r *schema.Resource, dataSource
 			result = append(result, item.(map[string]interface{}))
 		}
 	}
+	if _, ok := r.Schema["max_results"]; ok {
+		if maxResults := dataSourceMaxResults(d); maxResults > 0 && len(result) > maxResults {
+			result = result[:maxResults]
+		}
+	}
 	_, _, err = SdkSliceMapping(d, result, SdkSliceData{
 		IdField: dataSource.idFiled,
 		IdMappingFunc: func(idField string, item map[string]interface{}) string {
@@ -89,6 +121,7 @@ func mergeDataSourcesResp(d *schema.ResourceData, r *schema.Resource, dataSource
 			return SdkResponseAutoMapping(r, dataSource.targetField, item, dataSource.compute, dataSource.extra)
 		},
 		TargetName: dataSource.targetField,
+		filtered:   true,
 	})
 	return err
 }
@@ -103,3 +136,160 @@ func mergeNameRegex(d *schema.ResourceData, data map[string]interface{}, nameFie
 	}
 	return nil, false, err
 }
+
+// dataSourceFiltersSchema filters the data source results by their attributes on the client side
+func dataSourceFiltersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"values": {
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Set: schema.HashString,
+				},
+			},
+		},
+	}
+}
+
+// dataSourceFilter is a filter block of the data source, the wildcards of values are compiled to patterns once per read
+type dataSourceFilter struct {
+	path     []string
+	patterns []*regexp.Regexp
+}
+
+type dataSourceFilters []dataSourceFilter
+
+// newDataSourceFilters compiles the filter blocks of the data source, the name is the path of nested attributes
+// joined by dots such as instance_state.name, and the values support the wildcards * and ?
+func newDataSourceFilters(d *schema.ResourceData) dataSourceFilters {
+	raw, ok := d.GetOk("filter")
+	if !ok {
+		return nil
+	}
+	var filters dataSourceFilters
+	for _, f := range raw.(*schema.Set).List() {
+		filter := f.(map[string]interface{})
+		var patterns []*regexp.Regexp
+		for _, v := range filter["values"].(*schema.Set).List() {
+			patterns = append(patterns, regexp.MustCompile("^"+
+				strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(v.(string)))+"$"))
+		}
+		filters = append(filters, dataSourceFilter{
+			path:     strings.Split(filter["name"].(string), "."),
+			patterns: patterns,
+		})
+	}
+	return filters
+}
+
+// match checks the attributes with all the filters, a filter matches when any value of the attribute
+// at its path matches any of its patterns
+func (filters dataSourceFilters) match(attributes map[string]interface{}) bool {
+	for _, filter := range filters {
+		if !filter.match(attributeValues(attributes, filter.path)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (filter dataSourceFilter) match(values []string) bool {
+	for _, pattern := range filter.patterns {
+		for _, value := range values {
+			if pattern.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterDataSourceResults keeps the mapped results of data source matching all the filter blocks,
+// ids are filtered together with datas
+func filterDataSourceResults(d *schema.ResourceData, ids []string, datas []map[string]interface{}) ([]string, []map[string]interface{}) {
+	filters := newDataSourceFilters(d)
+	if filters == nil {
+		return ids, datas
+	}
+	var (
+		filteredIds   []string
+		filteredDatas []map[string]interface{}
+	)
+	for i, data := range datas {
+		if !filters.match(data) {
+			continue
+		}
+		if i < len(ids) {
+			filteredIds = append(filteredIds, ids[i])
+		}
+		filteredDatas = append(filteredDatas, data)
+	}
+	return filteredIds, filteredDatas
+}
+
+// filtersMatchPlugin drops the items whose mapped attributes do not match the filter blocks,
+// it runs before name_regex and max_results so the results are only truncated after filtering
+func filtersMatchPlugin(r *schema.Resource, dataSource ksyunDataSource, filters dataSourceFilters) matchPlugin {
+	return func(d *schema.ResourceData, data map[string]interface{}) (map[string]interface{}, bool, error) {
+		if filters.match(SdkResponseAutoMapping(r, dataSource.targetField, data, dataSource.compute, dataSource.extra)) {
+			return data, true, nil
+		}
+		return nil, true, nil
+	}
+}
+
+// attributeValues returns the string values of the attribute at path, the lists on path are expanded to all their elements
+// unless the path element is an index
+func attributeValues(value interface{}, path []string) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return nil
+		}
+		return attributeValues(v[path[0]], path[1:])
+	case []map[string]interface{}:
+		var items []interface{}
+		for _, item := range v {
+			items = append(items, item)
+		}
+		return attributeValues(items, path)
+	case []string:
+		var items []interface{}
+		for _, item := range v {
+			items = append(items, item)
+		}
+		return attributeValues(items, path)
+	case *schema.Set:
+		return attributeValues(v.List(), path)
+	case []interface{}:
+		if len(path) > 0 {
+			if i, err := strconv.Atoi(path[0]); err == nil {
+				if i < 0 || i >= len(v) {
+					return nil
+				}
+				return attributeValues(v[i], path[1:])
+			}
+		}
+		var values []string
+		for _, item := range v {
+			values = append(values, attributeValues(item, path)...)
+		}
+		return values
+	}
+	if len(path) > 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%v", value)}
+}
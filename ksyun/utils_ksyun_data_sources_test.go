@@ -0,0 +1,79 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDataSourceFiltersMatch(t *testing.T) {
+	a := assert.New(t)
+	attributes := map[string]interface{}{
+		"instance_name":  "web-1",
+		"instance_state": []interface{}{map[string]interface{}{"name": "active"}},
+		"key_id":         []string{"key-1", "key-2"},
+		"cpu":            4,
+		"tags":           map[string]interface{}{"tier": "web"},
+	}
+	filters := func(raw ...map[string]interface{}) *schema.ResourceData {
+		var filter []interface{}
+		for _, r := range raw {
+			filter = append(filter, r)
+		}
+		return schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+			"filter": dataSourceFiltersSchema(),
+		}, map[string]interface{}{"filter": filter})
+	}
+	filter := func(name string, values ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": name, "values": values}
+	}
+
+	a.True(newDataSourceFilters(filters()).match(attributes))
+	a.True(newDataSourceFilters(filters(filter("instance_name", "web-*"))).match(attributes))
+	a.True(newDataSourceFilters(filters(filter("instance_name", "db-?", "web-?"))).match(attributes))
+	a.False(newDataSourceFilters(filters(filter("instance_name", "web"))).match(attributes))
+	a.True(newDataSourceFilters(filters(filter("instance_state.name", "active"))).match(attributes))
+	a.True(newDataSourceFilters(filters(filter("instance_state.0.name", "active"))).match(attributes))
+	a.False(newDataSourceFilters(filters(filter("instance_state.1.name", "active"))).match(attributes))
+	a.True(newDataSourceFilters(filters(filter("key_id", "key-2"))).match(attributes))
+	a.True(newDataSourceFilters(filters(filter("cpu", "4"))).match(attributes))
+	a.True(newDataSourceFilters(filters(filter("tags.tier", "web"))).match(attributes))
+	a.False(newDataSourceFilters(filters(filter("tags.env", "*"))).match(attributes))
+	a.False(newDataSourceFilters(filters(filter("instance_name", "web-*"), filter("cpu", "8"))).match(attributes))
+
+	ids, datas := filterDataSourceResults(filters(filter("cpu", "4")), []string{"i-1", "i-2"}, []map[string]interface{}{
+		{"cpu": 8},
+		attributes,
+	})
+	a.Equal([]string{"i-2"}, ids)
+	a.Len(datas, 1)
+}
+
+func TestReadResourcesTags(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	c := Config{
+		AccessKey: fakeApiAccessKey,
+		SecretKey: fakeApiSecretKey,
+		Region:    fakeApiRegion,
+		Endpoints: map[string]string{"tag": api.server.URL},
+	}
+	client, err := c.Client()
+	a.Nil(err)
+
+	var ids []string
+	for i := 0; i < 150; i++ {
+		id := api.uuid()
+		ids = append(ids, id)
+		if i%2 == 0 {
+			api.tags[id] = map[string]string{"tier": "db"}
+		}
+	}
+	tags, err := readResourcesTags(client, "subnet", ids)
+	a.Nil(err)
+	a.Equal(2, api.called("tagv2", "ListTagsByResourceIds"))
+	a.Len(tags, 75)
+	a.Equal(map[string]string{"tier": "db"}, tags[ids[148]])
+	a.Nil(tags[ids[149]])
+}
@@ -1,22 +1,221 @@
 package ksyun
 
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// defaultPageParallel is the most pages fetched at the same time once the total count is known
+const defaultPageParallel = 4
+
 type pageCall func(map[string]interface{}) ([]interface{}, error)
 
+// pageResult is one page of a list call
+type pageResult struct {
+	items []interface{}
+	// total is the count of the items in all the pages, 0 when the api does not return it
+	total int
+	// nextToken is the token of the next page, empty on the last page
+	nextToken string
+}
+
+type pageResultCall func(map[string]interface{}) (pageResult, error)
+
+type pageOptions struct {
+	limitParam string
+	pageParam  string
+	limit      int
+	// start is the offset of the first page, the page param is the offset of each page
+	start int
+	// byToken walks the pages by the token returned in the previous page instead of the offset
+	byToken bool
+	// maxResults stops listing when there are enough items, 0 lists all the items
+	maxResults int
+	// parallel is the most pages fetched at the same time once the total count is known
+	parallel int
+}
+
+// pageResponseOptions describes where the items, total count and next page token are in the describe response
+type pageResponseOptions struct {
+	pageOptions
+	itemsField string
+	totalField string
+	tokenField string
+}
+
 func pageQuery(condition map[string]interface{}, limitParam string, pageParam string, limit int, start int, call pageCall) (data []interface{}, err error) {
-	offset := start
-	for {
-		var d []interface{}
-		condition[limitParam] = limit
-		condition[pageParam] = offset
-		d, err = call(condition)
+	return pageQueryWithOptions(condition, pageOptions{
+		limitParam: limitParam,
+		pageParam:  pageParam,
+		limit:      limit,
+		start:      start,
+	}, func(condition map[string]interface{}) (pageResult, error) {
+		items, err := call(condition)
+		return pageResult{items: items}, err
+	})
+}
+
+// pageQueryResponse lists the items of all the pages of a describe call,
+// the total count and the next page token are read from the response by options
+func pageQueryResponse(condition map[string]interface{}, options pageResponseOptions,
+	call func(map[string]interface{}) (*map[string]interface{}, error)) (data []interface{}, err error) {
+	return pageQueryWithOptions(condition, options.pageOptions, func(condition map[string]interface{}) (result pageResult, err error) {
+		resp, err := call(condition)
+		if err != nil {
+			return result, err
+		}
+		items, err := getSdkValue(options.itemsField, *resp)
+		if err != nil {
+			return result, err
+		}
+		if items != nil {
+			result.items = items.([]interface{})
+		}
+		if options.totalField != "" {
+			if total, err := getSdkValue(options.totalField, *resp); err == nil {
+				result.total, _ = strconv.Atoi(fmt.Sprintf("%v", total))
+			}
+		}
+		if options.tokenField != "" {
+			if token, err := getSdkValue(options.tokenField, *resp); err == nil && token != nil {
+				result.nextToken = fmt.Sprintf("%v", token)
+			}
+		}
+		return result, err
+	})
+}
+
+// pageQueryWithOptions lists the items of all the pages in order, the later pages are fetched concurrently
+// when the first page returns the total count and the pages are walked by offset
+func pageQueryWithOptions(condition map[string]interface{}, options pageOptions, call pageResultCall) (data []interface{}, err error) {
+	page := func(value interface{}) (pageResult, error) {
+		req := make(map[string]interface{}, len(condition)+2)
+		for k, v := range condition {
+			req[k] = v
+		}
+		req[options.limitParam] = options.limit
+		if value != nil {
+			req[options.pageParam] = value
+		}
+		return call(req)
+	}
+	enough := func() bool {
+		return options.maxResults > 0 && len(data) >= options.maxResults
+	}
+
+	if options.byToken {
+		var token interface{}
+		for {
+			result, err := page(token)
+			if err != nil {
+				return data, err
+			}
+			data = append(data, result.items...)
+			if enough() || result.nextToken == "" || len(result.items) < options.limit {
+				break
+			}
+			token = result.nextToken
+		}
+		return truncatePage(data, options.maxResults), nil
+	}
+
+	first, err := page(options.start)
+	if err != nil {
+		return data, err
+	}
+	data = append(data, first.items...)
+	if len(first.items) < options.limit || enough() {
+		return truncatePage(data, options.maxResults), nil
+	}
+
+	if first.total > 0 {
+		total := first.total
+		if options.maxResults > 0 && options.maxResults < total {
+			total = options.maxResults
+		}
+		var offsets []int
+		for offset := options.start + options.limit; offset < options.start+total; offset += options.limit {
+			offsets = append(offsets, offset)
+		}
+		pages, err := fetchPages(offsets, options.parallel, func(offset int) ([]interface{}, error) {
+			result, err := page(offset)
+			return result.items, err
+		})
+		if err != nil {
+			return data, err
+		}
+		for _, items := range pages {
+			data = append(data, items...)
+		}
+		return truncatePage(data, options.maxResults), nil
+	}
+
+	for offset := options.start + options.limit; ; offset += options.limit {
+		result, err := page(offset)
 		if err != nil {
 			return data, err
 		}
-		data = append(data, d...)
-		if len(d) < limit {
+		data = append(data, result.items...)
+		if len(result.items) < options.limit || enough() {
 			break
 		}
-		offset = offset + limit
 	}
-	return data, err
+	return truncatePage(data, options.maxResults), nil
+}
+
+// fetchPages calls fetch for each offset with at most parallel calls at the same time,
+// the pages are returned in the order of offsets and the error is the one of the first failed page
+func fetchPages(offsets []int, parallel int, fetch func(offset int) ([]interface{}, error)) ([][]interface{}, error) {
+	if parallel <= 0 {
+		parallel = defaultPageParallel
+	}
+	pages := make([][]interface{}, len(offsets))
+	errs := make([]error, len(offsets))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i, offset := range offsets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i, offset int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			pages[i], errs[i] = fetch(offset)
+		}(i, offset)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pages, nil
+}
+
+func truncatePage(data []interface{}, maxResults int) []interface{} {
+	if maxResults > 0 && len(data) > maxResults {
+		return data[:maxResults]
+	}
+	return data
+}
+
+// dataSourceMaxResults returns max_results of the data source, 0 means all the items
+func dataSourceMaxResults(d *schema.ResourceData) int {
+	if v, ok := d.GetOk("max_results"); ok {
+		return v.(int)
+	}
+	return 0
+}
+
+// dataSourcePageLimit returns the most items to read from the api for the data source,
+// name_regex, tags and filter drop items on the client side after reading, so all the items are read when one of them is set
+func dataSourcePageLimit(d *schema.ResourceData) int {
+	for _, key := range []string{"name_regex", "tags", "filter"} {
+		if _, ok := d.GetOk(key); ok {
+			return 0
+		}
+	}
+	return dataSourceMaxResults(d)
 }
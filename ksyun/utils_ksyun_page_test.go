@@ -0,0 +1,108 @@
+package ksyun
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakePages returns a page call over count items, the later pages answer faster to check the order of results
+func fakePages(count int, total bool) (pageResultCall, *[]map[string]interface{}) {
+	var (
+		mu    sync.Mutex
+		calls []map[string]interface{}
+	)
+	return func(condition map[string]interface{}) (pageResult, error) {
+		mu.Lock()
+		calls = append(calls, condition)
+		mu.Unlock()
+		limit := condition["MaxResults"].(int)
+		offset := 0
+		if token, ok := condition["NextToken"]; ok {
+			offset, _ = strconv.Atoi(fmt.Sprintf("%v", token))
+		}
+		if offset >= 100 {
+			return pageResult{}, fmt.Errorf("invalid offset %d", offset)
+		}
+		time.Sleep(time.Duration(count-offset) * time.Millisecond / 10)
+		result := pageResult{}
+		for i := offset; i < offset+limit && i < count; i++ {
+			result.items = append(result.items, i)
+		}
+		if total {
+			result.total = count
+		}
+		if offset+limit < count {
+			result.nextToken = strconv.Itoa(offset + limit)
+		}
+		return result, nil
+	}, &calls
+}
+
+func TestPageQueryWithOptions(t *testing.T) {
+	a := assert.New(t)
+	options := pageOptions{
+		limitParam: "MaxResults",
+		pageParam:  "NextToken",
+		limit:      10,
+	}
+	expected := func(count int) []interface{} {
+		var items []interface{}
+		for i := 0; i < count; i++ {
+			items = append(items, i)
+		}
+		return items
+	}
+
+	for _, total := range []bool{false, true} {
+		call, calls := fakePages(95, total)
+		data, err := pageQueryWithOptions(map[string]interface{}{"VpcId.1": "vpc-1"}, options, call)
+		a.Nil(err)
+		a.Equal(expected(95), data)
+		a.Len(*calls, 10)
+		a.Equal("vpc-1", (*calls)[9]["VpcId.1"])
+	}
+
+	// the pages are fetched by the token of the previous page
+	byToken := options
+	byToken.byToken = true
+	call, calls := fakePages(95, false)
+	data, err := pageQueryWithOptions(nil, byToken, call)
+	a.Nil(err)
+	a.Equal(expected(95), data)
+	a.Len(*calls, 10)
+	a.NotContains((*calls)[0], "NextToken")
+	a.Equal("10", (*calls)[1]["NextToken"])
+
+	// only the pages of max results are fetched
+	for _, o := range []pageOptions{options, byToken} {
+		o.maxResults = 25
+		call, calls = fakePages(95, true)
+		data, err = pageQueryWithOptions(nil, o, call)
+		a.Nil(err)
+		a.Equal(expected(25), data)
+		a.Len(*calls, 3)
+	}
+
+	call, _ = fakePages(200, true)
+	_, err = pageQueryWithOptions(nil, options, call)
+	a.EqualError(err, "invalid offset 100")
+}
+
+func TestPageQuery(t *testing.T) {
+	a := assert.New(t)
+	var offsets []interface{}
+	data, err := pageQuery(map[string]interface{}{}, "PageSize", "Page", 2, 1, func(condition map[string]interface{}) ([]interface{}, error) {
+		offsets = append(offsets, condition["Page"])
+		if len(offsets) > 2 {
+			return []interface{}{"c"}, nil
+		}
+		return []interface{}{"a", "b"}, nil
+	})
+	a.Nil(err)
+	a.Equal([]interface{}{"a", "b", "a", "b", "c"}, data)
+	a.Equal([]interface{}{1, 3, 5}, offsets)
+}
@@ -0,0 +1,47 @@
+package ksyun
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket which allows qps requests per second with a burst of qps
+type rateLimiter struct {
+	mu     sync.Mutex
+	qps    float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(qps int) *rateLimiter {
+	return &rateLimiter{
+		qps:    float64(qps),
+		burst:  float64(qps),
+		tokens: float64(qps),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available
+func (l *rateLimiter) Wait() {
+	time.Sleep(l.reserve(time.Now()))
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = l.tokens + elapsed.Seconds()*l.qps
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+	l.tokens = l.tokens - 1
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.qps * float64(time.Second))
+}
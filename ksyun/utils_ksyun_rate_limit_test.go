@@ -0,0 +1,24 @@
+package ksyun
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	a := assert.New(t)
+	now := time.Now()
+	l := newRateLimiter(2)
+	l.last = now
+
+	a.Equal(time.Duration(0), l.reserve(now))
+	a.Equal(time.Duration(0), l.reserve(now))
+	a.Equal(500*time.Millisecond, l.reserve(now))
+	a.Equal(time.Second, l.reserve(now))
+
+	// tokens are refilled by elapsed time but never more than burst
+	a.Equal(time.Duration(0), l.reserve(now.Add(10*time.Second)))
+	a.Equal(time.Duration(0), l.reserve(now.Add(10*time.Second)))
+	a.Equal(500*time.Millisecond, l.reserve(now.Add(10*time.Second)))
+}
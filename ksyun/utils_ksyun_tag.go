@@ -5,6 +5,33 @@ import (
 	"strings"
 )
 
+// ignoreTagsConfig is the tag keys managed outside of terraform, which are neither read nor replaced
+type ignoreTagsConfig struct {
+	keys        []string
+	keyPrefixes []string
+}
+
+func (c *ignoreTagsConfig) ignored(key string) bool {
+	if c == nil {
+		return false
+	}
+	for _, k := range c.keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range c.keyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ignoreTagsConfig) empty() bool {
+	return c == nil || (len(c.keys) == 0 && len(c.keyPrefixes) == 0)
+}
+
 func tagsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeMap,
@@ -12,6 +39,34 @@ func tagsSchema() *schema.Schema {
 	}
 }
 
+// tagsAllSchema is the effective tags of resource, which merges provider default_tags and resource tags
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+	}
+}
+
+// tagsCustomizeDiff plans tags_all with provider default_tags and resource tags, resource tags win on conflict
+func tagsCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("tags_all")
+	}
+	client := meta.(*KsyunClient)
+	tagsAll := make(map[string]string)
+	for k, v := range client.defaultTags {
+		if !client.ignoreTags.ignored(k) {
+			tagsAll[k] = v
+		}
+	}
+	for k, v := range diff.Get("tags").(map[string]interface{}) {
+		if !client.ignoreTags.ignored(k) {
+			tagsAll[k] = v.(string)
+		}
+	}
+	return diff.SetNew("tags_all", tagsAll)
+}
+
 func mergeTagsData(d *schema.ResourceData, data *map[string]interface{}, client *KsyunClient, resourceType string) (err error) {
 	var tags []interface{}
 	tagService := TagService{client}
@@ -26,13 +81,141 @@ func mergeTagsData(d *schema.ResourceData, data *map[string]interface{}, client
 		}
 		return err
 	}
+	configTags := d.Get("tags").(map[string]interface{})
 	tagMap := make(map[string]interface{})
+	tagAllMap := make(map[string]interface{})
 	for _, tag := range tags {
 		_m := tag.(map[string]interface{})
-		tagMap[_m["TagKey"].(string)] = _m["TagValue"].(string)
+		key := _m["TagKey"].(string)
+		value := _m["TagValue"].(string)
+		if client.ignoreTags.ignored(key) {
+			continue
+		}
+		tagAllMap[key] = value
+		// the tag comes from default_tags will not be reported as drift when resource never declared it
+		if defaultValue, ok := client.defaultTags[key]; ok && defaultValue == value {
+			if _, declared := configTags[key]; !declared {
+				continue
+			}
+		}
+		tagMap[key] = value
 	}
 	if len(tagMap) > 0 {
 		(*data)["Tags"] = tagMap
 	}
+	// tags_all is always set, otherwise it stays unknown in state and is planned as computed every time
+	(*data)["TagsAll"] = tagAllMap
 	return err
 }
+
+// maxTagResourceIds is the most resource ids in one ListTagsByResourceIds call
+const maxTagResourceIds = 100
+
+// tagsFilterSchema filters the data source results by tags, the empty value matches any value of the tag key.
+// it is only added to the data sources of the resource types supported by the tag service
+func tagsFilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+	}
+}
+
+// tagsMatchPlugin keeps the data source item which has all tags of the tags filter,
+// the tags of all the items in collection are read together when the first item is matched
+func tagsMatchPlugin(client *KsyunClient, resourceType string, idField string, collection []interface{}) matchPlugin {
+	var resourceTags map[string]map[string]string
+	return func(d *schema.ResourceData, item map[string]interface{}) (map[string]interface{}, bool, error) {
+		v, ok := d.GetOk("tags")
+		if !ok || len(v.(map[string]interface{})) == 0 {
+			return nil, false, nil
+		}
+		if resourceTags == nil {
+			var err error
+			resourceTags, err = readResourcesTags(client, resourceType, resourceIds(collection, idField))
+			if err != nil {
+				return nil, true, err
+			}
+		}
+		id, _ := item[idField].(string)
+		if !matchTags(v.(map[string]interface{}), resourceTags[id]) {
+			return nil, true, nil
+		}
+		return item, true, nil
+	}
+}
+
+// readResourcesTags reads the tags of resources by ListTagsByResourceIds with up to maxTagResourceIds ids in each call,
+// and returns the tags of each resource id
+func readResourcesTags(client *KsyunClient, resourceType string, ids []string) (map[string]map[string]string, error) {
+	tagService := TagService{client}
+	resourceTags := make(map[string]map[string]string)
+	for start := 0; start < len(ids); start += maxTagResourceIds {
+		end := start + maxTagResourceIds
+		if end > len(ids) {
+			end = len(ids)
+		}
+		tags, err := tagService.ReadTagsByResourceIds(map[string]interface{}{
+			"ResourceType":  resourceType,
+			"ResourceUuids": strings.Join(ids[start:end], ","),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			m := tag.(map[string]interface{})
+			id, _ := m["ResourceUuid"].(string)
+			key, _ := m["TagKey"].(string)
+			value, _ := m["TagValue"].(string)
+			if _, ok := resourceTags[id]; !ok {
+				resourceTags[id] = make(map[string]string)
+			}
+			resourceTags[id][key] = value
+		}
+	}
+	return resourceTags, nil
+}
+
+func resourceIds(items []interface{}, idField string) []string {
+	var ids []string
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			if id, ok := m[idField].(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func matchTags(filter map[string]interface{}, tags map[string]string) bool {
+	for k, v := range filter {
+		value, ok := tags[k]
+		if !ok {
+			return false
+		}
+		if expect := v.(string); expect != "" && expect != value {
+			return false
+		}
+	}
+	return true
+}
+
+// filterResourcesByTags filters the resources of data sources which still save results by themselves
+func filterResourcesByTags(d *schema.ResourceData, client *KsyunClient, resourceType string, idField string, items []interface{}) ([]interface{}, error) {
+	v, ok := d.GetOk("tags")
+	if !ok || len(v.(map[string]interface{})) == 0 {
+		return items, nil
+	}
+	resourceTags, err := readResourcesTags(client, resourceType, resourceIds(items, idField))
+	if err != nil {
+		return nil, err
+	}
+	var result []interface{}
+	for _, item := range items {
+		id, _ := item.(map[string]interface{})[idField].(string)
+		if matchTags(v.(map[string]interface{}), resourceTags[id]) {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
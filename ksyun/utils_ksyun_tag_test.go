@@ -0,0 +1,71 @@
+package ksyun
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIgnoreTagsConfig(t *testing.T) {
+	a := assert.New(t)
+	var nilConfig *ignoreTagsConfig
+	a.False(nilConfig.ignored("cost-center"))
+	a.True(nilConfig.empty())
+
+	c := &ignoreTagsConfig{
+		keys:        []string{"cost-center"},
+		keyPrefixes: []string{"auto-"},
+	}
+	a.False(c.empty())
+	a.True(c.ignored("cost-center"))
+	a.True(c.ignored("auto-stop"))
+	a.False(c.ignored("cost"))
+	a.False(c.ignored("env"))
+}
+
+func TestMatchTags(t *testing.T) {
+	a := assert.New(t)
+	tags := map[string]string{
+		"env":  "prod",
+		"team": "infra",
+	}
+	a.True(matchTags(map[string]interface{}{}, tags))
+	a.True(matchTags(map[string]interface{}{"env": "prod"}, tags))
+	a.True(matchTags(map[string]interface{}{"env": "prod", "team": ""}, tags))
+	a.False(matchTags(map[string]interface{}{"env": "test"}, tags))
+	a.False(matchTags(map[string]interface{}{"owner": ""}, tags))
+}
+
+func TestKeepIgnoredTagsBeforeCall(t *testing.T) {
+	a := assert.New(t)
+	api := newFakeKsyunApi(t)
+	defer api.server.Close()
+	c := Config{
+		AccessKey: fakeApiAccessKey,
+		SecretKey: fakeApiSecretKey,
+		Region:    fakeApiRegion,
+		Endpoints: map[string]string{"tag": api.server.URL},
+	}
+	client, err := c.Client()
+	a.Nil(err)
+	client.ignoreTags = &ignoreTagsConfig{keys: []string{"owner"}}
+
+	id := api.uuid()
+	api.tags[id] = map[string]string{"owner": "ops", "env": "test"}
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId(id)
+	tagService := TagService{client}
+	before := tagService.keepIgnoredTagsBeforeCall("vpc")
+
+	param := map[string]interface{}{"ResourceType": "vpc"}
+	ok, err := before(d, client, ApiCall{param: &param})
+	a.Nil(err)
+	a.True(ok)
+	a.Equal("owner", param["Tag_0_Key"])
+	a.Equal("ops", param["Tag_0_Value"])
+
+	param = map[string]interface{}{"ResourceType": "vpc", "Tag_0_Key": "env", "Tag_0_Value": "prod"}
+	_, err = before(d, client, ApiCall{param: &param})
+	a.Nil(err)
+	a.Equal("owner", param["Tag_1_Key"])
+}
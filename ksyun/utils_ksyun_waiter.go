@@ -0,0 +1,25 @@
+package ksyun
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// stateWaiterTiming overrides the delay and intervals of the state waiters,
+// the unit tests on the fake api use a few milliseconds instead of waiting on the real api
+type stateWaiterTiming struct {
+	delay        time.Duration
+	pollInterval time.Duration
+	minTimeout   time.Duration
+}
+
+// waitForState waits for the state of conf, the timing of conf is replaced by waiterTiming of the client when it is set
+func (client *KsyunClient) waitForState(conf *resource.StateChangeConf) (interface{}, error) {
+	if timing := client.waiterTiming; timing != nil {
+		conf.Delay = timing.delay
+		conf.PollInterval = timing.pollInterval
+		conf.MinTimeout = timing.minTimeout
+	}
+	return conf.WaitForState()
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"net"
 	"regexp"
+	"time"
 )
 
 var validateName = validation.StringMatch(
@@ -44,6 +45,16 @@ func validateIpAddress(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+// validateDuration ensures that the string value can be parsed by time.ParseDuration
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, err := time.ParseDuration(value); err != nil {
+		errors = append(errors, fmt.Errorf(
+			"%q must contain a valid duration like 500ms or 2s, got error parsing: %s", k, err))
+	}
+	return
+}
+
 func validateSubnetType(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if value != "Reserve" && value != "Normal" && value != "Physical" {
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"runtime"
@@ -13,31 +14,97 @@ const RespFormat = "ACTION:%s;REQ:%+v;RESP:%+v"
 const ErrFormat = "ACTION:%s;REQ:%+v;ERR:%+v"
 const AllFormat = "ACTION:%s;REQ:%+v;RESP:%+v;ERR:%+v"
 
+// formatFields is the json fields following request of the api call formats
+var formatFields = map[string][]string{
+	ReqFormat:  nil,
+	RespFormat: {"response"},
+	ErrFormat:  {"error"},
+	AllFormat:  {"response", "error"},
+}
+
+// ApiCallEntry is the structured log of an api call
+type ApiCallEntry struct {
+	Action     string      `json:"action"`
+	Service    string      `json:"service"`
+	Region     string      `json:"region"`
+	RequestId  string      `json:"request_id,omitempty"`
+	LatencyMs  int64       `json:"latency_ms"`
+	StatusCode int         `json:"status_code,omitempty"`
+	Retries    int         `json:"retries,omitempty"`
+	Request    interface{} `json:"request,omitempty"`
+	Response   interface{} `json:"response,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
+
+// Debug logs an api call as a json object when format is one of ReqFormat, RespFormat, ErrFormat and AllFormat,
+// the sensitive fields of request and response are redacted. The object only adds the caller to the ApiCall entry
+// of the same call, so it is logged at TRACE level to keep one object per call with TF_LOG=DEBUG
 func Debug(format string, action string, req interface{}, v ...interface{}) {
-	_, file, line, _ := runtime.Caller(skip)
-	start := strings.LastIndex(file, "/")
-	if start != -1 {
-		file = file[start+1:]
+	fields, ok := formatFields[format]
+	if !ok {
+		log.Printf(prefix("DEBUG")+format, action, Redact(req), redactAll(v))
+		return
 	}
-	message := fmt.Sprintf("[DEBUG] {%v:%v}", file, line)
-	log.Printf(message+format, action, req, v)
+	entry := map[string]interface{}{
+		"caller":  caller(skip),
+		"action":  action,
+		"request": Redact(req),
+	}
+	for i, field := range fields {
+		if i >= len(v) {
+			break
+		}
+		if err, ok := v[i].(error); ok {
+			entry[field] = err.Error()
+		} else if v[i] != nil {
+			entry[field] = Redact(v[i])
+		}
+	}
+	printJson("TRACE", entry)
 }
+
 func DebugInfo(format string, info interface{}) {
-	_, file, line, _ := runtime.Caller(skip)
-	start := strings.LastIndex(file, "/")
-	if start != -1 {
-		file = file[start+1:]
-	}
-	message := fmt.Sprintf("[DEBUG] {%v:%v}", file, line)
-	log.Printf(message+format, info)
+	log.Printf(prefix("DEBUG")+format, Redact(info))
 }
 
 func Info(format string, v ...interface{}) {
-	_, file, line, _ := runtime.Caller(skip)
+	log.Printf(prefix("INFO")+format, redactAll(v))
+}
+
+// ApiCall logs the entry as a json object, the sensitive fields of request and response are redacted
+func ApiCall(entry ApiCallEntry) {
+	entry.Request = Redact(entry.Request)
+	entry.Response = Redact(entry.Response)
+	printJson("DEBUG", entry)
+}
+
+func printJson(level string, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("[%s] %+v", level, v)
+		return
+	}
+	log.Printf("[%s] %s", level, b)
+}
+
+func redactAll(v []interface{}) []interface{} {
+	result := make([]interface{}, len(v))
+	for i, item := range v {
+		result[i] = Redact(item)
+	}
+	return result
+}
+
+func prefix(level string) string {
+	return fmt.Sprintf("[%s] {%v}", level, caller(skip+1))
+}
+
+// caller returns the file and line of the code calling the logger, depth is the frames between the logger function and caller
+func caller(depth int) string {
+	_, file, line, _ := runtime.Caller(depth + 1)
 	start := strings.LastIndex(file, "/")
 	if start != -1 {
 		file = file[start+1:]
 	}
-	message := fmt.Sprintf("[INFO] {%v:%v}", file, line)
-	log.Printf(message+format, v)
+	return fmt.Sprintf("%v:%v", file, line)
 }
@@ -1,7 +1,104 @@
 package logger
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
 
 func TestDebug(t *testing.T) {
 	Debug(AllFormat, "test", "request", "response", nil)
 }
+
+func captureLog(f func()) string {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	f()
+	return buf.String()
+}
+
+func TestDebugJson(t *testing.T) {
+	req := map[string]interface{}{
+		"InstanceName":     "foo",
+		"InstancePassword": "Pass123",
+	}
+	resp := &map[string]interface{}{
+		"AccessKey": map[string]interface{}{
+			"AccessKeyId":     "AKLT",
+			"SecretAccessKey": "secret",
+		},
+	}
+	output := captureLog(func() {
+		Debug(AllFormat, "RunInstances", &req, resp, errors.New("failed"))
+	})
+	if strings.Contains(output, "Pass123") || strings.Contains(output, "\"secret\"") {
+		t.Fatalf("secret is not redacted: %s", output)
+	}
+	if req["InstancePassword"] != "Pass123" {
+		t.Fatalf("request is modified by redaction")
+	}
+	if !strings.Contains(output, "[TRACE] {") {
+		t.Errorf("api call of caller is not logged at trace level: %s", output)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(output[strings.Index(output, "{"):]), &entry); err != nil {
+		t.Fatalf("log is not json: %s, %s", output, err)
+	}
+	if entry["action"] != "RunInstances" || entry["error"] != "failed" || !strings.HasPrefix(entry["caller"].(string), "logger_test.go:") {
+		t.Errorf("unexpected log %s", output)
+	}
+	if entry["request"].(map[string]interface{})["InstanceName"] != "foo" {
+		t.Errorf("unexpected request %s", output)
+	}
+}
+
+func TestAddRedactKeys(t *testing.T) {
+	defer func() {
+		redactMu.Lock()
+		defer redactMu.Unlock()
+		redactKeys = append([]string{}, DefaultRedactKeys...)
+	}()
+	AddRedactKeys([]string{"MobilePhone"})
+	// the keys of another provider configuration never remove the keys added before
+	AddRedactKeys([]string{"Email", "mobilephone"})
+	redacted := Redact(map[string]interface{}{
+		"mobilephone": "13800000000",
+		"UserEmail":   "a@b.c",
+		"PassWord":    "p",
+		"List":        []interface{}{map[string]string{"PrivateKey": "k", "Name": "n"}},
+	}).(map[string]interface{})
+	if redacted["mobilephone"] != Redacted || redacted["UserEmail"] != Redacted || redacted["PassWord"] != Redacted {
+		t.Errorf("unexpected redaction %+v", redacted)
+	}
+	item := redacted["List"].([]interface{})[0].(map[string]string)
+	if item["PrivateKey"] != Redacted || item["Name"] != "n" {
+		t.Errorf("unexpected redaction %+v", item)
+	}
+}
+
+func TestApiCall(t *testing.T) {
+	output := captureLog(func() {
+		ApiCall(ApiCallEntry{
+			Action:     "CreateDBInstance",
+			Service:    "krds",
+			Region:     "cn-beijing-6",
+			RequestId:  "b0fb0d3e",
+			LatencyMs:  12,
+			StatusCode: 200,
+			Request:    &map[string]interface{}{"MasterUserPassword": "Pass123"},
+		})
+	})
+	if strings.Contains(output, "Pass123") {
+		t.Fatalf("secret is not redacted: %s", output)
+	}
+	for _, s := range []string{`"service":"krds"`, `"region":"cn-beijing-6"`, `"request_id":"b0fb0d3e"`, `"latency_ms":12`, `"status_code":200`} {
+		if !strings.Contains(output, s) {
+			t.Errorf("%s not in log %s", s, output)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the value of sensitive fields in logs
+const Redacted = "******"
+
+// DefaultRedactKeys is the sensitive field names, a field is redacted when its lower case name contains any of them,
+// such as InstancePassword, MasterUserPassword, PassWord, PrivateKey and SecretAccessKey
+var DefaultRedactKeys = []string{
+	"password",
+	"privatekey",
+	"secret",
+	"securitytoken",
+	"sessiontoken",
+}
+
+var (
+	redactMu   sync.RWMutex
+	redactKeys = append([]string{}, DefaultRedactKeys...)
+)
+
+// AddRedactKeys adds sensitive field names to the ones used by Redact, the keys are never removed,
+// so the provider configurations of all aliases are merged and none of them turns off the redaction of another
+func AddRedactKeys(keys []string) {
+	redactMu.Lock()
+	defer redactMu.Unlock()
+	for _, k := range keys {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" && !containsKey(redactKeys, k) {
+			redactKeys = append(redactKeys, k)
+		}
+	}
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRedactKey reports whether the field named key is sensitive
+func IsRedactKey(key string) bool {
+	key = strings.ToLower(key)
+	redactMu.RLock()
+	defer redactMu.RUnlock()
+	for _, k := range redactKeys {
+		if strings.Contains(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of v whose sensitive fields are replaced by Redacted,
+// the maps and slices are copied deeply and v itself is never modified
+func Redact(v interface{}) interface{} {
+	switch value := v.(type) {
+	case *map[string]interface{}:
+		if value == nil {
+			return value
+		}
+		return Redact(*value)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			if IsRedactKey(k) {
+				result[k] = Redacted
+			} else {
+				result[k] = Redact(item)
+			}
+		}
+		return result
+	case map[string]string:
+		result := make(map[string]string, len(value))
+		for k, item := range value {
+			if IsRedactKey(k) {
+				result[k] = Redacted
+			} else {
+				result[k] = item
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = Redact(item)
+		}
+		return result
+	case []map[string]interface{}:
+		result := make([]interface{}, len(value))
+		for i, item := range value {
+			result[i] = Redact(item)
+		}
+		return result
+	}
+	return v
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"log"
+
 	"github.com/hashicorp/terraform-plugin-sdk/plugin"
 	"github.com/terraform-providers/terraform-provider-ksyun/ksyun"
 )
@@ -9,4 +11,7 @@ func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: ksyun.Provider,
 	})
+	if err := ksyun.WriteApiMetrics(); err != nil {
+		log.Printf("[ERROR] %s", err)
+	}
 }